@@ -0,0 +1,233 @@
+package controller
+
+import (
+	"golang-test1/app/dto"
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ProductBarcodesResponse represents the barcodes of a product.
+type ProductBarcodesResponse struct {
+	Barcodes []model.ProductBarcode `json:"barcodes"`
+}
+
+// ProductBarcodeResponse represents a successful barcode response.
+type ProductBarcodeResponse struct {
+	Barcode model.ProductBarcode `json:"barcode"`
+}
+
+// BarcodeLookupResponse represents the product found for a scanned barcode.
+type BarcodeLookupResponse struct {
+	Barcode model.ProductBarcode `json:"barcode"`
+	Product dto.Product          `json:"product"`
+}
+
+// GetProductByBarcode func finds the product of a scanned barcode.
+// @Description Find the product carrying a barcode. EAN-8, UPC-A, EAN-13 and GTIN-14 codes are accepted; a UPC-A code also finds the product when it was registered as EAN-13 or GTIN-14, and the other way around.
+// @Summary look up a product by barcode
+// @Tags Barcode
+// @Accept json
+// @Produce json
+// @Param code path string true "Scanned barcode"
+// @Success 200 {object} BarcodeLookupResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/products/barcode/{code} [get]
+func GetProductByBarcode(c *fiber.Ctx) error {
+	code := strings.TrimSpace(c.Params("code"))
+	if !validator.ValidBarcode(code, validator.BarcodeType(code)) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid barcode",
+		})
+	}
+
+	barcodeRepo := repo.NewBarcodeRepository(database.GetDB())
+	barcode, err := barcodeRepo.GetByGTIN(validator.NormalizeGTIN(code))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "no product with this barcode",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	product, err := productRepo.GetByID(barcode.ProductID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "no product with this barcode",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"barcode": barcode,
+		"product": dto.ToProduct(product),
+	})
+}
+
+// GetProductBarcodes func lists the barcodes of a product.
+// @Description Get the barcodes of a product, the primary barcode first.
+// @Summary get product barcodes
+// @Tags Barcode
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID format)"
+// @Success 200 {object} ProductBarcodesResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/products/{id}/barcodes [get]
+func GetProductBarcodes(c *fiber.Ctx) error {
+	productID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	if _, err := productRepo.GetByID(productID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	barcodeRepo := repo.NewBarcodeRepository(database.GetDB())
+	barcodes, err := barcodeRepo.GetByProductID(productID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"barcodes": barcodes,
+	})
+}
+
+// AddProductBarcode func adds a barcode to a product.
+// @Description Add a barcode to a product. The check digit must match the barcode type, and a barcode belongs to a single product.
+// @Summary add a product barcode
+// @Tags Barcode
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID format)"
+// @Param barcode body model.ProductBarcodeInput true "Barcode"
+// @Success 201 {object} ProductBarcodeResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/products/{id}/barcodes [post]
+func AddProductBarcode(c *fiber.Ctx) error {
+	productID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	input := &model.ProductBarcodeInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	input.Code = strings.TrimSpace(input.Code)
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	barcodeType := input.Type
+	if barcodeType == "" {
+		barcodeType = validator.BarcodeType(input.Code)
+	}
+	if !validator.ValidBarcode(input.Code, barcodeType) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "barcode is not a valid " + barcodeType + " code",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	if _, err := productRepo.GetByID(productID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	gtin := validator.NormalizeGTIN(input.Code)
+
+	barcodeRepo := repo.NewBarcodeRepository(database.GetDB())
+	if existing, err := barcodeRepo.GetByGTIN(gtin); err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "barcode already belongs to product " + existing.ProductID.String(),
+		})
+	}
+
+	barcode := &model.ProductBarcode{
+		ID:        uuid.New(),
+		ProductID: productID,
+		Code:      input.Code,
+		Type:      barcodeType,
+		GTIN:      gtin,
+		IsPrimary: input.IsPrimary,
+		CreatedAt: time.Now(),
+	}
+
+	if err := barcodeRepo.Create(barcode); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"barcode": barcode,
+	})
+}
+
+// DeleteProductBarcode func removes a barcode from a product.
+// @Description Remove a barcode from a product.
+// @Summary delete a product barcode
+// @Tags Barcode
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID format)"
+// @Param barcode_id path string true "Barcode ID (UUID format)"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,403,404 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/products/{id}/barcodes/{barcode_id} [delete]
+func DeleteProductBarcode(c *fiber.Ctx) error {
+	productID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	barcodeID, err := uuid.Parse(c.Params("barcode_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid barcode ID format",
+		})
+	}
+
+	barcodeRepo := repo.NewBarcodeRepository(database.GetDB())
+	if err := barcodeRepo.Delete(productID, barcodeID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "barcode not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "barcode deleted successfully",
+	})
+}
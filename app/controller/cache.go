@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"golang-test1/app/model"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setCacheValidators sets the ETag and Last-Modified headers derived from the given
+// catalogue version and reports whether the client's cached copy is still fresh,
+// in which case the handler should answer with 304 Not Modified.
+func setCacheValidators(c *fiber.Ctx, scope string, version *model.CatalogueVersion) bool {
+	// HTTP dates have a one second resolution
+	lastModified := version.LastModified.UTC().Truncate(time.Second)
+
+	// The query string is part of the tag because it selects what a listing returns
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d|%d|%s",
+		scope,
+		version.LastModified.UnixNano(),
+		version.Count,
+		c.Request().URI().QueryString(),
+	)))
+	etag := `W/"` + hex.EncodeToString(sum[:]) + `"`
+
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderLastModified, lastModified.Format(http.TimeFormat))
+
+	if c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+		return false
+	}
+
+	// If-None-Match takes precedence over If-Modified-Since (RFC 7232, section 6)
+	if noneMatch := c.Get(fiber.HeaderIfNoneMatch); noneMatch != "" {
+		return etagMatches(noneMatch, etag)
+	}
+
+	if modifiedSince := c.Get(fiber.HeaderIfModifiedSince); modifiedSince != "" {
+		since, err := http.ParseTime(modifiedSince)
+		return err == nil && !lastModified.After(since)
+	}
+
+	return false
+}
+
+// etagMatches reports whether the If-None-Match header value matches the etag
+// using the weak comparison function.
+func etagMatches(noneMatch, etag string) bool {
+	for _, candidate := range strings.Split(noneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
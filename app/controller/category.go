@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"golang-test1/app/dto"
 	"golang-test1/app/model"
 	repo "golang-test1/app/repository"
@@ -29,6 +30,21 @@ type CategoriesResponse struct {
 	Categories []dto.Category `json:"categories"`
 }
 
+// CategoryTreeResponse represents a category tree response.
+type CategoryTreeResponse struct {
+	Categories []dto.CategoryNode `json:"categories"`
+}
+
+// BreadcrumbsResponse represents the ancestor chain of a category, top category first.
+type BreadcrumbsResponse struct {
+	Breadcrumbs []dto.Category `json:"breadcrumbs"`
+}
+
+// CategoryMergeResponse represents the outcome of a category merge.
+type CategoryMergeResponse struct {
+	Merge model.CategoryMerge `json:"merge"`
+}
+
 // ProductCountResponse represents a product count response.
 type ProductCountResponse struct {
 	ProductCount int `json:"product_count"`
@@ -40,14 +56,14 @@ type SuccessResponse struct {
 }
 
 // CreateCategory func for creating a new category.
-// @Description Create a new category.
+// @Description Create a new category. The parent is checked like a move.
 // @Summary create a new category
 // @Tags Category
 // @Accept json
 // @Produce json
 // @Param category body model.CategoryInput true "Create new category"
 // @Success 201 {object} CategoryResponse
-// @Failure 400,401,500 {object} CatErrorResponse "Error"
+// @Failure 400,401,409,500 {object} CatErrorResponse "Error"
 // @Security ApiKeyAuth
 // @Router /api/v1/categories [post]
 func CreateCategory(c *fiber.Ctx) error {
@@ -89,7 +105,7 @@ func CreateCategory(c *fiber.Ctx) error {
 
 	// Create category in database
 	if err := categoryRepo.Create(category); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(categoryHierarchyStatus(err)).JSON(fiber.Map{
 			"msg": err.Error(),
 		})
 	}
@@ -138,13 +154,14 @@ func GetCategory(c *fiber.Ctx) error {
 }
 
 // GetCategoryBySlug func gets a category by slug.
-// @Description Get a category by slug.
+// @Description Get a category by slug. The slug of a category merged into another one answers with a redirect to the slug of that category, along with the category.
 // @Summary get a category by slug
 // @Tags Category
 // @Accept json
 // @Produce json
 // @Param slug path string true "Category Slug"
 // @Success 200 {object} CategoryResponse
+// @Success 301 {object} CategoryResponse "Slug of a merged category"
 // @Failure 404 {object} CatErrorResponse "Error"
 // @Router /api/v1/categories/slug/{slug} [get]
 func GetCategoryBySlug(c *fiber.Ctx) error {
@@ -157,8 +174,17 @@ func GetCategoryBySlug(c *fiber.Ctx) error {
 	// Get category from database
 	category, err := categoryRepo.GetBySlug(slugStr)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"msg": "category not found",
+		// The slug may belong to a category that was merged into another one
+		target, redirectErr := categoryRepo.GetRedirect(slugStr)
+		if redirectErr != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"msg": "category not found",
+			})
+		}
+
+		c.Location("/api/v1/categories/slug/" + target.Slug)
+		return c.Status(fiber.StatusMovedPermanently).JSON(fiber.Map{
+			"category": dto.ToCategory(target),
 		})
 	}
 
@@ -169,7 +195,7 @@ func GetCategoryBySlug(c *fiber.Ctx) error {
 }
 
 // UpdateCategory func updates a category.
-// @Description Update a category.
+// @Description Update a category. A new parent is checked like a move.
 // @Summary update a category
 // @Tags Category
 // @Accept json
@@ -177,7 +203,7 @@ func GetCategoryBySlug(c *fiber.Ctx) error {
 // @Param id path string true "Category ID (UUID format)"
 // @Param category body model.CategoryInput true "Update category"
 // @Success 200 {object} CategoryResponse
-// @Failure 400,401,404,500 {object} CatErrorResponse "Error"
+// @Failure 400,401,404,409,500 {object} CatErrorResponse "Error"
 // @Security ApiKeyAuth
 // @Router /api/v1/categories/{id} [put]
 func UpdateCategory(c *fiber.Ctx) error {
@@ -228,7 +254,7 @@ func UpdateCategory(c *fiber.Ctx) error {
 
 	// Update category in database
 	if err := categoryRepo.Update(existingCategory); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(categoryHierarchyStatus(err)).JSON(fiber.Map{
 			"msg": err.Error(),
 		})
 	}
@@ -247,8 +273,218 @@ func UpdateCategory(c *fiber.Ctx) error {
 	})
 }
 
+// categoryHierarchyStatus maps the errors of placing a category in the tree to a status code
+func categoryHierarchyStatus(err error) int {
+	switch {
+	case errors.Is(err, repo.ErrParentCategoryNotFound),
+		errors.Is(err, repo.ErrParentCategoryInactive),
+		errors.Is(err, repo.ErrCategoryOrderMismatch),
+		errors.Is(err, repo.ErrCategoryMergeSelf):
+		return fiber.StatusBadRequest
+	case errors.Is(err, repo.ErrMergeTargetNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, repo.ErrCategoryCycle),
+		errors.Is(err, repo.ErrCategoryTooDeep):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+// MoveCategory func moves a category in the tree.
+// @Description Move a category below another parent, or to the top without one, and renumber the display order of its old and new siblings. Moves below the category itself or one of its descendants, below an inactive or deleted parent, or deeper than the tree allows are rejected.
+// @Summary move a category
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID (UUID format)"
+// @Param move body model.CategoryMoveInput true "New parent and position"
+// @Success 200 {object} CategoryResponse
+// @Failure 400,401,404,409,500 {object} CatErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/categories/{id}/move [put]
+func MoveCategory(c *fiber.Ctx) error {
+	// Parse ID from URL parameter
+	idStr := c.Params("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid category ID format",
+		})
+	}
+
+	// Parse request body
+	input := &model.CategoryMoveInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Validate input
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	// Get repository
+	categoryRepo := repo.NewCategoryRepository(database.GetDB())
+
+	// Check if category exists
+	if _, err := categoryRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "category not found",
+		})
+	}
+
+	// Move the category
+	if err := categoryRepo.Move(id, input.ParentID, input.Position); err != nil {
+		return c.Status(categoryHierarchyStatus(err)).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Get moved category
+	category, err := categoryRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Return moved category
+	return c.JSON(fiber.Map{
+		"category": dto.ToCategory(category),
+	})
+}
+
+// ReorderCategories func sets the display order of sibling categories.
+// @Description Set the display order of all children of a parent, or of all top categories without one. The category IDs must list every sibling exactly once; the display order becomes their position, counted from 0.
+// @Summary reorder sibling categories
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param reorder body model.CategoryReorderInput true "Parent and sibling IDs in the new order"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,404,500 {object} CatErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/categories/reorder [put]
+func ReorderCategories(c *fiber.Ctx) error {
+	// Parse request body
+	input := &model.CategoryReorderInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Validate input
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	// Get repository
+	categoryRepo := repo.NewCategoryRepository(database.GetDB())
+
+	// Check if parent category exists
+	if input.ParentID != nil {
+		if _, err := categoryRepo.GetByID(*input.ParentID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"msg": "parent category not found",
+			})
+		}
+	}
+
+	// Renumber the siblings
+	if err := categoryRepo.Reorder(input.ParentID, input.CategoryIDs); err != nil {
+		return c.Status(categoryHierarchyStatus(err)).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Return success message
+	return c.JSON(fiber.Map{
+		"msg": "categories reordered successfully",
+	})
+}
+
+// MergeCategory func merges a category into another one.
+// @Description Move the products and child categories of a category into a target category, redirect its slug to the target, repoint saved searches and collection rules using it and remove it, in one transaction. Children that would end up too deep or below an inactive target are rejected. With preview the outcome is reported without changing anything.
+// @Summary merge a category into another one
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param id path string true "Source category ID (UUID format)"
+// @Param merge body model.CategoryMergeInput true "Target category and preview flag"
+// @Success 200 {object} CategoryMergeResponse
+// @Failure 400,401,404,409,500 {object} CatErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/categories/{id}/merge [post]
+func MergeCategory(c *fiber.Ctx) error {
+	// Parse ID from URL parameter
+	idStr := c.Params("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid category ID format",
+		})
+	}
+
+	// Parse request body
+	input := &model.CategoryMergeInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Validate input
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	// Get repository
+	categoryRepo := repo.NewCategoryRepository(database.GetDB())
+
+	// Check if both categories exist
+	if _, err := categoryRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "category not found",
+		})
+	}
+	if _, err := categoryRepo.GetByID(input.TargetID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "target category not found",
+		})
+	}
+
+	// Merge, or only work out the merge for a preview
+	merge, err := categoryRepo.Merge(id, input.TargetID, input.Preview)
+	if err != nil {
+		return c.Status(categoryHierarchyStatus(err)).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Return the outcome
+	return c.JSON(fiber.Map{
+		"merge": merge,
+	})
+}
+
 // DeleteCategory func deletes a category.
-// @Description Delete a category.
+// @Description Delete a category. A category with products or child categories, or used by collection rules, cannot be deleted.
 // @Summary delete a category
 // @Tags Category
 // @Accept json
@@ -291,6 +527,10 @@ func DeleteCategory(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 				"msg": "category cannot be deleted because it has child categories",
 			})
+		} else if errors.Is(err, repo.ErrCategoryInCollections) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"msg": "category cannot be deleted because collections select products by it",
+			})
 		}
 
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -310,10 +550,25 @@ func DeleteCategory(c *fiber.Ctx) error {
 // @Tags Category
 // @Accept json
 // @Produce json
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Param If-Modified-Since header string false "Last-Modified date of a cached copy"
 // @Success 200 {object} CategoriesResponse
+// @Success 304 "Not Modified"
 // @Failure 500 {object} CatErrorResponse "Error"
 // @Router /api/v1/categories [get]
 func ListCategories(c *fiber.Ctx) error {
+	// Answer conditional requests before loading the categories
+	productRepo := repo.NewProductRepository(database.GetDB())
+	version, err := productRepo.GetCatalogueVersion()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if setCacheValidators(c, "categories", version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	// Get repository
 	categoryRepo := repo.NewCategoryRepository(database.GetDB())
 
@@ -331,13 +586,109 @@ func ListCategories(c *fiber.Ctx) error {
 	})
 }
 
+// GetCategoryTree func gets the categories as a nested tree.
+// @Description Get the category tree, top categories first, with published product counts per category and per subtree.
+// @Summary get the category tree
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param depth query integer false "Levels below the top categories to include (default: all)"
+// @Param active_only query boolean false "Leave out inactive categories and their branches"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Param If-Modified-Since header string false "Last-Modified date of a cached copy"
+// @Success 200 {object} CategoryTreeResponse
+// @Success 304 "Not Modified"
+// @Failure 400,500 {object} CatErrorResponse "Error"
+// @Router /api/v1/categories/tree [get]
+func GetCategoryTree(c *fiber.Ctx) error {
+	// Parse query parameters
+	depth := c.QueryInt("depth", 0)
+	if depth < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "depth must not be negative",
+		})
+	}
+	activeOnly := c.QueryBool("active_only", false)
+
+	// Answer conditional requests before loading the tree
+	productRepo := repo.NewProductRepository(database.GetDB())
+	version, err := productRepo.GetCatalogueVersion()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if setCacheValidators(c, "category-tree", version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	// Get repository
+	categoryRepo := repo.NewCategoryRepository(database.GetDB())
+
+	// Get the tree rows
+	rows, err := categoryRepo.GetTree(depth, activeOnly)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Return the nested categories
+	return c.JSON(fiber.Map{
+		"categories": dto.ToCategoryTree(rows),
+	})
+}
+
+// GetCategoryBreadcrumbs func gets the ancestor chain of a category.
+// @Description Get the breadcrumbs of a category, from its top category down to the category itself.
+// @Summary get category breadcrumbs
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID (UUID format)"
+// @Success 200 {object} BreadcrumbsResponse
+// @Failure 400,404,500 {object} CatErrorResponse "Error"
+// @Router /api/v1/categories/{id}/breadcrumbs [get]
+func GetCategoryBreadcrumbs(c *fiber.Ctx) error {
+	// Parse ID from URL parameter
+	idStr := c.Params("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid category ID format",
+		})
+	}
+
+	// Get repository
+	categoryRepo := repo.NewCategoryRepository(database.GetDB())
+
+	// Get the ancestor chain
+	categories, err := categoryRepo.GetBreadcrumbs(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if len(categories) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "category not found",
+		})
+	}
+
+	// Return breadcrumbs
+	return c.JSON(fiber.Map{
+		"breadcrumbs": dto.ToCategories(categories),
+	})
+}
+
 // GetCategoryProductCount func gets the count of products in a category.
-// @Description Get the count of products in a category.
+// @Description Get the count of published products in a category.
 // @Summary get product count for a category
 // @Tags Category
 // @Accept json
 // @Produce json
 // @Param id path string true "Category ID (UUID format)"
+// @Param include_descendants query boolean false "Count the distinct products of the category and all its subcategories"
 // @Success 200 {object} ProductCountResponse "product count"
 // @Failure 400,404,500 {object} CatErrorResponse "Error"
 // @Router /api/v1/categories/{id}/product-count [get]
@@ -363,7 +714,12 @@ func GetCategoryProductCount(c *fiber.Ctx) error {
 	}
 
 	// Get product count
-	count, err := categoryRepo.GetProductCount(id)
+	var count int
+	if c.QueryBool("include_descendants", false) {
+		count, err = categoryRepo.GetSubtreeProductCount(id)
+	} else {
+		count, err = categoryRepo.GetProductCount(id)
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"msg": err.Error(),
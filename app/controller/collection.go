@@ -0,0 +1,604 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"golang-test1/app/dto"
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/gosimple/slug"
+)
+
+// CollectionResponse represents a successful collection response.
+type CollectionResponse struct {
+	Collection model.Collection `json:"collection"`
+}
+
+// CollectionsResponse represents a paginated collection list.
+type CollectionsResponse struct {
+	Page        int                `json:"page"`
+	Size        int                `json:"page_size"`
+	Total       int                `json:"total"`
+	Collections []model.Collection `json:"collections"`
+}
+
+// CollectionProductsResponse represents a collection with a page of its products.
+type CollectionProductsResponse struct {
+	Collection model.Collection `json:"collection"`
+	Page       int              `json:"page"`
+	Size       int              `json:"page_size"`
+	Total      int              `json:"total"`
+	Products   []dto.Product    `json:"products"`
+}
+
+// CollectionPreviewResponse represents the first products matched by collection rules.
+type CollectionPreviewResponse struct {
+	Page     int           `json:"page"`
+	Size     int           `json:"page_size"`
+	Total    int           `json:"total"`
+	Products []dto.Product `json:"products"`
+}
+
+// CollectionOverridesResponse represents the manual overrides of a collection.
+type CollectionOverridesResponse struct {
+	Overrides []model.CollectionOverride `json:"overrides"`
+}
+
+// collectionSort returns the sort of a collection listing, created_at desc by default
+func collectionSort(sortBy, sortOrder string) (string, string) {
+	validSortFields := map[string]bool{
+		"name":           true,
+		"price":          true,
+		"created_at":     true,
+		"stock_quantity": true,
+		"sku":            true,
+	}
+	if !validSortFields[sortBy] {
+		sortBy = "created_at"
+	}
+	if sortOrder != "asc" && sortOrder != "desc" {
+		sortOrder = "desc"
+	}
+	return sortBy, sortOrder
+}
+
+// checkCollectionRules returns why the rules are inconsistent, or an empty string
+func checkCollectionRules(rules model.CollectionRules) string {
+	if rules.MinPrice != nil && rules.MaxPrice != nil && *rules.MinPrice > *rules.MaxPrice {
+		return "min_price must not be greater than max_price"
+	}
+	if rules.MinStock != nil && rules.MaxStock != nil && *rules.MinStock > *rules.MaxStock {
+		return "min_stock must not be greater than max_stock"
+	}
+	if rules.IncludeDescendants && rules.CategoryID == nil {
+		return "include_descendants requires category_id"
+	}
+	if rules.CategoryID != nil {
+		categoryRepo := repo.NewCategoryRepository(database.GetDB())
+		if _, err := categoryRepo.GetByID(*rules.CategoryID); err != nil {
+			return "category not found"
+		}
+	}
+	return ""
+}
+
+// ListCollections func lists collections.
+// @Description List active collections ordered by name. Admins also see inactive collections.
+// @Summary list collections
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Success 200 {object} CollectionsResponse
+// @Failure 500 {object} ErrorResponse "Error"
+// @Router /api/v1/collections [get]
+func ListCollections(c *fiber.Ctx) error {
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	collectionRepo := repo.NewCollectionRepository(database.GetDB())
+	collections, total, err := collectionRepo.List(offset, pageSize, OptionalRole(c) != "admin")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"page":        pageNo,
+		"page_size":   pageSize,
+		"total":       total,
+		"collections": collections,
+	})
+}
+
+// GetCollectionBySlug func gets a collection with its products.
+// @Description Get an active collection by slug with a page of its published products: those matching the rules, plus the included and minus the excluded products.
+// @Summary get a collection by slug
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param slug path string true "Collection slug"
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Success 200 {object} CollectionProductsResponse
+// @Failure 404,500 {object} ErrorResponse "Error"
+// @Router /api/v1/collections/slug/{slug} [get]
+func GetCollectionBySlug(c *fiber.Ctx) error {
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	collectionRepo := repo.NewCollectionRepository(database.GetDB())
+	collection, err := collectionRepo.GetBySlug(c.Params("slug"))
+	if err != nil || !collection.IsActive {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "collection not found",
+		})
+	}
+
+	sortBy, sortOrder := collectionSort(collection.SortBy, collection.SortOrder)
+	products, total, err := collectionRepo.GetProducts(&collection.ID, collection.Rules, sortBy, sortOrder, offset, pageSize)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"collection": collection,
+		"page":       pageNo,
+		"page_size":  pageSize,
+		"total":      total,
+		"products":   dto.ToProducts(products),
+	})
+}
+
+// CreateCollection func for creating a new collection.
+// @Description Create a rule-based collection. The slug is derived from the name.
+// @Summary create a new collection
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param collection body model.CollectionInput true "Create new collection"
+// @Success 201 {object} CollectionResponse
+// @Failure 400,401,403,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/collections [post]
+func CreateCollection(c *fiber.Ctx) error {
+	input := &model.CollectionInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	if msg := checkCollectionRules(input.Rules); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	collectionRepo := repo.NewCollectionRepository(database.GetDB())
+
+	collectionSlug := slug.Make(strings.TrimSpace(input.Name))
+	exists, err := collectionRepo.SlugExists(collectionSlug, uuid.Nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if exists {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "a collection with this name already exists",
+		})
+	}
+
+	now := time.Now()
+	sortBy, sortOrder := collectionSort(input.SortBy, input.SortOrder)
+	collection := &model.Collection{
+		ID:          uuid.New(),
+		Name:        strings.TrimSpace(input.Name),
+		Slug:        collectionSlug,
+		Description: input.Description,
+		Rules:       input.Rules,
+		SortBy:      sortBy,
+		SortOrder:   sortOrder,
+		IsActive:    input.IsActive == nil || *input.IsActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := collectionRepo.Create(collection); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"collection": collection,
+	})
+}
+
+// GetCollection func gets a collection by ID.
+// @Description Get a collection by ID, active or not.
+// @Summary get a collection
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID format)"
+// @Success 200 {object} CollectionResponse
+// @Failure 400,401,403,404 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/collections/{id} [get]
+func GetCollection(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid collection ID format",
+		})
+	}
+
+	collectionRepo := repo.NewCollectionRepository(database.GetDB())
+	collection, err := collectionRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "collection not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"collection": collection,
+	})
+}
+
+// UpdateCollection func updates a collection.
+// @Description Update a collection and its rules. The slug follows the name.
+// @Summary update a collection
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID format)"
+// @Param collection body model.CollectionInput true "Update collection"
+// @Success 200 {object} CollectionResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/collections/{id} [put]
+func UpdateCollection(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid collection ID format",
+		})
+	}
+
+	collectionRepo := repo.NewCollectionRepository(database.GetDB())
+	collection, err := collectionRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "collection not found",
+		})
+	}
+
+	input := &model.CollectionInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	if msg := checkCollectionRules(input.Rules); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	collectionSlug := slug.Make(strings.TrimSpace(input.Name))
+	exists, err := collectionRepo.SlugExists(collectionSlug, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if exists {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "a collection with this name already exists",
+		})
+	}
+
+	collection.Name = strings.TrimSpace(input.Name)
+	collection.Slug = collectionSlug
+	collection.Description = input.Description
+	collection.Rules = input.Rules
+	collection.SortBy, collection.SortOrder = collectionSort(input.SortBy, input.SortOrder)
+	if input.IsActive != nil {
+		collection.IsActive = *input.IsActive
+	}
+
+	if err := collectionRepo.Update(collection); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"collection": collection,
+	})
+}
+
+// DeleteCollection func deletes a collection.
+// @Description Delete a collection with its overrides. Products are not affected.
+// @Summary delete a collection
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID format)"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/collections/{id} [delete]
+func DeleteCollection(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid collection ID format",
+		})
+	}
+
+	collectionRepo := repo.NewCollectionRepository(database.GetDB())
+	if _, err := collectionRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "collection not found",
+		})
+	}
+
+	if err := collectionRepo.Delete(id); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "collection deleted successfully",
+	})
+}
+
+// PreviewCollection func lists the products matched by collection rules.
+// @Description List a page of the published products matched by collection rules, before saving them. Overrides are not applied.
+// @Summary preview collection rules
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param preview body model.CollectionPreviewInput true "Rules to preview"
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Success 200 {object} CollectionPreviewResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/collections/preview [post]
+func PreviewCollection(c *fiber.Ctx) error {
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	input := &model.CollectionPreviewInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	if msg := checkCollectionRules(input.Rules); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	sortBy, sortOrder := collectionSort(input.SortBy, input.SortOrder)
+
+	collectionRepo := repo.NewCollectionRepository(database.GetDB())
+	products, total, err := collectionRepo.GetProducts(nil, input.Rules, sortBy, sortOrder, offset, pageSize)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"page":      pageNo,
+		"page_size": pageSize,
+		"total":     total,
+		"products":  dto.ToProducts(products),
+	})
+}
+
+// GetCollectionOverrides func gets the manual overrides of a collection.
+// @Description Get the products included in or excluded from a collection whatever its rules.
+// @Summary get collection overrides
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID format)"
+// @Success 200 {object} CollectionOverridesResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/collections/{id}/overrides [get]
+func GetCollectionOverrides(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid collection ID format",
+		})
+	}
+
+	collectionRepo := repo.NewCollectionRepository(database.GetDB())
+	if _, err := collectionRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "collection not found",
+		})
+	}
+
+	overrides, err := collectionRepo.GetOverrides(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"overrides": overrides,
+	})
+}
+
+// SetCollectionOverride func includes a product in a collection or excludes it.
+// @Description Include a product in a collection or exclude it, whatever the rules. An earlier override of the product is replaced.
+// @Summary set a collection override
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID format)"
+// @Param product_id path string true "Product ID (UUID format)"
+// @Param override body model.CollectionOverrideInput true "Override mode"
+// @Success 200 {object} CollectionOverridesResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/collections/{id}/overrides/{product_id} [put]
+func SetCollectionOverride(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid collection ID format",
+		})
+	}
+
+	productID, err := uuid.Parse(c.Params("product_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	input := &model.CollectionOverrideInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	collectionRepo := repo.NewCollectionRepository(database.GetDB())
+	if _, err := collectionRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "collection not found",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	if _, err := productRepo.GetByID(productID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	if err := collectionRepo.SetOverride(id, productID, input.Mode); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	overrides, err := collectionRepo.GetOverrides(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"overrides": overrides,
+	})
+}
+
+// DeleteCollectionOverride func removes the override of a product.
+// @Description Remove the override of a product, so the rules alone decide whether it is in the collection.
+// @Summary delete a collection override
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID format)"
+// @Param product_id path string true "Product ID (UUID format)"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/collections/{id}/overrides/{product_id} [delete]
+func DeleteCollectionOverride(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid collection ID format",
+		})
+	}
+
+	productID, err := uuid.Parse(c.Params("product_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	collectionRepo := repo.NewCollectionRepository(database.GetDB())
+	if err := collectionRepo.DeleteOverride(id, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"msg": "override not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "override deleted successfully",
+	})
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"errors"
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// PinnedProductsResponse represents the products pinned within a category.
+type PinnedProductsResponse struct {
+	Products []model.PinnedProduct `json:"products"`
+}
+
+// GetCategoryMerchandising func gets the products pinned within a category.
+// @Description Get the products pinned within a category, in position order. Listings with sort_by=merchandised show them first.
+// @Summary get pinned products of a category
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID (UUID format)"
+// @Success 200 {object} PinnedProductsResponse
+// @Failure 400,401,404,500 {object} CatErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/categories/{id}/merchandising [get]
+func GetCategoryMerchandising(c *fiber.Ctx) error {
+	// Parse ID from URL parameter
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid category ID format",
+		})
+	}
+
+	categoryRepo := repo.NewCategoryRepository(database.GetDB())
+
+	// Check if category exists
+	if _, err := categoryRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "category not found",
+		})
+	}
+
+	products, err := categoryRepo.GetPinnedProducts(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"products": products,
+	})
+}
+
+// SetCategoryMerchandising func pins products within a category.
+// @Description Pin products within a category at their position in the list, counted from 0, and unpin the others. Every product must already be in the category; an empty list unpins all products.
+// @Summary set pinned products of a category
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID (UUID format)"
+// @Param merchandising body model.CategoryMerchandisingInput true "Products to pin, in order"
+// @Success 200 {object} PinnedProductsResponse
+// @Failure 400,401,404,500 {object} CatErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/categories/{id}/merchandising [put]
+func SetCategoryMerchandising(c *fiber.Ctx) error {
+	// Parse ID from URL parameter
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid category ID format",
+		})
+	}
+
+	// Parse request body
+	input := &model.CategoryMerchandisingInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Validate input
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	// A product has a single position
+	seen := make(map[uuid.UUID]bool, len(input.ProductIDs))
+	for _, productID := range input.ProductIDs {
+		if seen[productID] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "duplicate product " + productID.String(),
+			})
+		}
+		seen[productID] = true
+	}
+
+	categoryRepo := repo.NewCategoryRepository(database.GetDB())
+
+	// Check if category exists
+	if _, err := categoryRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "category not found",
+		})
+	}
+
+	if err := categoryRepo.SetPinnedProducts(id, input.ProductIDs); err != nil {
+		if errors.Is(err, repo.ErrProductNotInCategory) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	products, err := categoryRepo.GetPinnedProducts(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"products": products,
+	})
+}
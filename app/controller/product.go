@@ -1,20 +1,32 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"golang-test1/app/dto"
 	"golang-test1/app/model"
 	repo "golang-test1/app/repository"
+	"golang-test1/app/worker"
+	"golang-test1/pkg/config"
 	"golang-test1/pkg/validator"
 	"golang-test1/platform/database"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
+// ProductBatchResponse represents the result of a batch product lookup.
+type ProductBatchResponse struct {
+	Found    int                      `json:"found"`
+	NotFound int                      `json:"not_found"`
+	Results  []dto.ProductBatchResult `json:"results"`
+}
+
 // CreateProduct func for creating a new product.
-// @Description Create a new product.
+// @Description Create a new product as a draft. It is not public until it is published, see the publish status route. The initial stock is held in the default warehouse.
 // @Summary create a new product
 // @Tags Product
 // @Accept json
@@ -52,6 +64,7 @@ func CreateProduct(c *fiber.Ctx) error {
 		Price:         productInput.Price,
 		StockQuantity: productInput.StockQuantity,
 		Status:        productInput.Status,
+		PublishStatus: model.PublishStatusDraft,
 		Attributes:    productInput.Attributes,
 		CreatedAt:     now,
 		UpdatedAt:     now,
@@ -87,14 +100,18 @@ func CreateProduct(c *fiber.Ctx) error {
 }
 
 // GetProduct func gets a single product by ID.
-// @Description Get product details by ID.
+// @Description Get product details by ID. Only published products are found, except for editors and admins. Views of authenticated users are added to their recently viewed products.
 // @Summary get a product
 // @Tags Product
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID (UUID format)"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Param If-Modified-Since header string false "Last-Modified date of a cached copy"
 // @Success 200 {object} dto.Product
+// @Success 304 "Not Modified"
 // @Failure 400,404 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
 // @Router /api/v1/products/{id} [get]
 func GetProduct(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
@@ -105,6 +122,32 @@ func GetProduct(c *fiber.Ctx) error {
 	}
 
 	productRepo := repo.NewProductRepository(database.GetDB())
+
+	// Hide products that are not published from the public
+	publishStatus, err := productRepo.GetPublishStatus(id)
+	if err != nil || (publishStatus != model.PublishStatusPublished && !canSeeUnpublished(c)) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	// Answer conditional requests before loading the product
+	version, err := productRepo.GetVersion(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	// Record the view for authenticated users, also when answering from cache
+	if userID, ok := OptionalUserID(c); ok {
+		worker.RecordView(userID, id)
+	}
+
+	if setCacheValidators(c, "product", version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	product, err := productRepo.GetByID(id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -118,7 +161,7 @@ func GetProduct(c *fiber.Ctx) error {
 }
 
 // UpdateProduct func for updating a product.
-// @Description Update an existing product.
+// @Description Update an existing product. The edits of a published product are saved as its pending draft and the live product is left unchanged until the draft is published; publishing a draft keeps the live stock. A change of stock quantity is applied to the default warehouse and cannot take the stock below what the other warehouses hold.
 // @Summary update a product
 // @Tags Product
 // @Accept json
@@ -126,6 +169,7 @@ func GetProduct(c *fiber.Ctx) error {
 // @Param id path string true "Product ID (UUID format)"
 // @Param product body model.ProductInput true "Update product"
 // @Success 200 {object} dto.Product "Ok"
+// @Success 202 {object} model.ProductDraft "Saved as the pending draft of a published product"
 // @Failure 400,401,404,500 {object} ErrorResponse "Error"
 // @Security ApiKeyAuth
 // @Router /api/v1/products/{id} [put]
@@ -163,6 +207,36 @@ func UpdateProduct(c *fiber.Ctx) error {
 		})
 	}
 
+	// Keep the edits of a live product apart until they are published
+	if existingProduct.PublishStatus == model.PublishStatusPublished {
+		var updatedBy *uuid.UUID
+		if userID, ok := OptionalUserID(c); ok {
+			updatedBy = &userID
+		}
+
+		now := time.Now()
+		draft := &model.ProductDraft{
+			ProductID: id,
+			Data:      *productInput,
+			Status:    model.PublishStatusDraft,
+			UpdatedBy: updatedBy,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		publishingRepo := repo.NewPublishingRepository(database.GetDB())
+		if err := publishingRepo.SaveDraft(draft); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+			"product": dto.ToProduct(existingProduct),
+			"draft":   draft,
+		})
+	}
+
 	// Update product
 	existingProduct.SKU = productInput.SKU
 	existingProduct.Name = productInput.Name
@@ -183,6 +257,12 @@ func UpdateProduct(c *fiber.Ctx) error {
 
 	// Save updated product
 	if err := productRepo.Update(existingProduct, productInput.CategoryIDs); err != nil {
+		if errors.Is(err, repo.ErrStockAllocated) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"msg": err.Error(),
 		})
@@ -202,14 +282,14 @@ func UpdateProduct(c *fiber.Ctx) error {
 }
 
 // DeleteProduct func for deleting a product.
-// @Description Delete a product.
+// @Description Delete a product. A product that is on purchase orders cannot be deleted.
 // @Summary delete a product
 // @Tags Product
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID (UUID format)"
 // @Success 200 {object} interface{} "Ok"
-// @Failure 400,401,404,500 {object} ErrorResponse "Error"
+// @Failure 400,401,404,409,500 {object} ErrorResponse "Error"
 // @Security ApiKeyAuth
 // @Router /api/v1/products/{id} [delete]
 func DeleteProduct(c *fiber.Ctx) error {
@@ -231,6 +311,12 @@ func DeleteProduct(c *fiber.Ctx) error {
 
 	// Delete product
 	if err := productRepo.Delete(id); err != nil {
+		if errors.Is(err, repo.ErrProductHasPurchaseOrders) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"msg": "product cannot be deleted because it is on purchase orders, set it inactive instead",
+			})
+		}
+
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"msg": err.Error(),
 		})
@@ -241,26 +327,177 @@ func DeleteProduct(c *fiber.Ctx) error {
 	})
 }
 
+// CloneProduct func for creating a product from an existing one.
+// @Description Copy a product into a new draft: attributes, categories and prices are copied, stock starts at 0. Without a SKU or name, a unique SKU and a name with a "(Copy)" suffix are generated. With include_relations, the clone also gets the similar products of the original until recommendations are recomputed.
+// @Summary clone a product
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID format)"
+// @Param clone body model.ProductCloneInput false "Clone options"
+// @Success 201 {object} dto.Product "Created"
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/products/{id}/clone [post]
+func CloneProduct(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	// Parse the optional request body
+	input := &model.ProductCloneInput{}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+	}
+
+	input.SKU = strings.TrimSpace(input.SKU)
+	input.Name = strings.TrimSpace(input.Name)
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	source, err := productRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	// Pick the SKU: the requested one must be free, otherwise the first free candidate
+	candidates := cloneSKUCandidates(source.SKU)
+	if input.SKU != "" {
+		candidates = []string{input.SKU}
+	}
+	taken, err := productRepo.GetBySKUs(candidates)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	takenSKUs := make(map[string]bool, len(taken))
+	for _, product := range taken {
+		takenSKUs[product.SKU] = true
+	}
+	sku := ""
+	for _, candidate := range candidates {
+		if !takenSKUs[candidate] {
+			sku = candidate
+			break
+		}
+	}
+	if sku == "" {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "product with this SKU already exists",
+		})
+	}
+
+	name := input.Name
+	if name == "" {
+		name = repo.TruncateRunes(source.Name, 100-len(" (Copy)")) + " (Copy)"
+	}
+
+	categoryIDs := make([]uuid.UUID, len(source.Categories))
+	for i, category := range source.Categories {
+		categoryIDs[i] = category.ID
+	}
+
+	now := time.Now()
+	product := &model.Product{
+		ID:            uuid.New(),
+		SKU:           sku,
+		Name:          name,
+		Description:   source.Description,
+		Price:         source.Price,
+		SalePrice:     source.SalePrice,
+		CostPrice:     source.CostPrice,
+		StockQuantity: 0,
+		Status:        source.Status,
+		PublishStatus: model.PublishStatusDraft,
+		Attributes:    source.Attributes,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := productRepo.Create(product, categoryIDs); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	if input.IncludeRelations {
+		recommendationRepo := repo.NewRecommendationRepository(database.GetDB())
+		if err := recommendationRepo.CopySimilarities(source.ID, product.ID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+	}
+
+	// Get complete product with categories
+	createdProduct, err := productRepo.GetByID(product.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"product": dto.ToProduct(createdProduct),
+	})
+}
+
+// cloneSKUCandidates returns the SKUs tried for a clone: SKU-COPY, SKU-COPY-2 up to
+// SKU-COPY-10, and a random one as a last resort. SKUs are at most 50 characters.
+func cloneSKUCandidates(sku string) []string {
+	base := repo.TruncateRunes(sku, 50-len("-COPY-10"))
+	candidates := []string{base + "-COPY"}
+	for i := 2; i <= 10; i++ {
+		candidates = append(candidates, fmt.Sprintf("%s-COPY-%d", base, i))
+	}
+	random := strings.ToUpper(strings.ReplaceAll(uuid.NewString(), "-", "")[:8])
+	candidates = append(candidates, repo.TruncateRunes(sku, 50-len("-COPY-")-len(random))+"-COPY-"+random)
+	return candidates
+}
+
 // GetProducts func for listing products with filtering and pagination.
-// @Description List all products with optional filtering and pagination.
+// @Description List published products with optional filtering and pagination. Editors and admins can list products in other publish statuses. Search results are not cached, every search is recorded for the search analytics.
 // @Summary list products
 // @Tags Product
 // @Accept json
 // @Produce json
 // @Param page query integer false "Page number"
 // @Param page_size query integer false "Page size"
-// @Param search query string false "Search term for name, SKU or description"
+// @Param search query string false "Search term for name, SKU or description, typo-tolerant and expanded with synonyms"
 // @Param category_id query string false "Filter by category ID (UUID format)"
+// @Param include_descendants query boolean false "Also match products of the subcategories of category_id"
 // @Param status query string false "Filter by status (active, inactive, out_of_stock)"
+// @Param publish_status query string false "Editors and admins only: filter by publish status (draft, in_review, scheduled, published, archived, all), published by default"
 // @Param min_price query number false "Filter by minimum price"
 // @Param max_price query number false "Filter by maximum price"
 // @Param min_stock query integer false "Filter by minimum stock quantity"
 // @Param max_stock query integer false "Filter by maximum stock quantity"
+// @Param min_rating query number false "Filter by minimum average rating (1-5), leaving out products without approved reviews"
 // @Param created_after query string false "Filter by creation date (RFC3339 format)"
 // @Param created_before query string false "Filter by creation date (RFC3339 format)"
-// @Param sort_by query string false "Sort field (name, price, created_at, stock_quantity)"
+// @Param sort_by query string false "Sort field (name, price, created_at, stock_quantity, sku, rating, merchandised). rating sorts by average rating, then review count. merchandised needs category_id: pinned products first, then the others in the configured fallback order"
 // @Param sort_order query string false "Sort order (asc, desc)"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Param If-Modified-Since header string false "Last-Modified date of a cached copy"
 // @Success 200 {array} dto.Product
+// @Success 304 "Not Modified"
 // @Failure 400,500 {object} ErrorResponse "Error"
 // @Router /api/v1/products [get]
 func GetProducts(c *fiber.Ctx) error {
@@ -271,6 +508,7 @@ func GetProducts(c *fiber.Ctx) error {
 	// Get filter parameters
 	search := c.Query("search")
 	status := c.Query("status")
+	publishStatus := model.PublishStatusPublished
 	sortBy := c.Query("sort_by", "created_at")
 	sortOrder := c.Query("sort_order", "desc")
 
@@ -285,6 +523,7 @@ func GetProducts(c *fiber.Ctx) error {
 		}
 		categoryID = &id
 	}
+	includeDescendants := c.QueryBool("include_descendants", false)
 
 	// Parse price range parameters
 	var minPrice, maxPrice *float64
@@ -328,6 +567,18 @@ func GetProducts(c *fiber.Ctx) error {
 		maxStock = &val
 	}
 
+	// Parse rating parameter
+	var minRating *float64
+	if c.Query("min_rating") != "" {
+		val, err := strconv.ParseFloat(c.Query("min_rating"), 64)
+		if err != nil || val < 1 || val > 5 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid min_rating, use a number from 1 to 5",
+			})
+		}
+		minRating = &val
+	}
+
 	// Parse date range parameters
 	var createdAfter, createdBefore *time.Time
 	if c.Query("created_after") != "" {
@@ -356,12 +607,47 @@ func GetProducts(c *fiber.Ctx) error {
 		"created_at":     true,
 		"stock_quantity": true,
 		"sku":            true,
+		"rating":         true,
 	}
 	validSortOrders := map[string]bool{
 		"asc":  true,
 		"desc": true,
 	}
 
+	// Editors and admins may look past the published catalogue
+	if c.Query("publish_status") != "" && canSeeUnpublished(c) {
+		validPublishStatuses := map[string]bool{
+			model.PublishStatusDraft:     true,
+			model.PublishStatusInReview:  true,
+			model.PublishStatusScheduled: true,
+			model.PublishStatusPublished: true,
+			model.PublishStatusArchived:  true,
+			"all":                        true,
+		}
+		if !validPublishStatuses[c.Query("publish_status")] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid publish_status",
+			})
+		}
+		publishStatus = c.Query("publish_status")
+		if publishStatus == "all" {
+			publishStatus = ""
+		}
+	}
+
+	// Merchandised listings put the pinned products of the category first and
+	// order the others by the configured fallback
+	merchandised := sortBy == "merchandised"
+	if merchandised {
+		if categoryID == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "sort_by merchandised requires category_id",
+			})
+		}
+		sortBy = config.CatalogCfg().MerchandisedFallbackSort
+		sortOrder = config.CatalogCfg().MerchandisedFallbackOrder
+	}
+
 	if !validSortFields[sortBy] {
 		sortBy = "created_at"
 	}
@@ -369,12 +655,30 @@ func GetProducts(c *fiber.Ctx) error {
 		sortOrder = "desc"
 	}
 
-	// Get products from repository with enhanced filtering
 	productRepo := repo.NewProductRepository(database.GetDB())
+
+	// Answer conditional requests before running the listing queries. Searches are
+	// logged for analytics, so they are not cached and always reach the server.
+	searching := strings.TrimSpace(search) != ""
+	if searching {
+		c.Set(fiber.HeaderCacheControl, "no-store")
+	} else {
+		version, err := productRepo.GetCatalogueVersion()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+		if setCacheValidators(c, "products", version) {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+	}
+
+	// Get products from repository with enhanced filtering
 	products, total, err := productRepo.ListWithFilters(
-		offset, pageSize, search, categoryID, status,
-		minPrice, maxPrice, minStock, maxStock,
-		createdAfter, createdBefore, sortBy, sortOrder,
+		offset, pageSize, search, categoryID, includeDescendants, status, publishStatus,
+		minPrice, maxPrice, minStock, maxStock, minRating,
+		createdAfter, createdBefore, nil, nil, merchandised, sortBy, sortOrder,
 	)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -382,6 +686,18 @@ func GetProducts(c *fiber.Ctx) error {
 		})
 	}
 
+	// Log the search for analytics, once per search rather than per page
+	if searching && pageNo == 1 {
+		var userID *uuid.UUID
+		if id, ok := OptionalUserID(c); ok {
+			userID = &id
+		}
+		searchRepo := repo.NewSearchRepository(database.GetDB())
+		if err := searchRepo.LogSearch(search, total, userID); err != nil {
+			logr.Error(err)
+		}
+	}
+
 	return c.JSON(fiber.Map{
 		"page":      pageNo,
 		"page_size": pageSize,
@@ -391,7 +707,7 @@ func GetProducts(c *fiber.Ctx) error {
 }
 
 // GetProductCategories func for getting categories of a product.
-// @Description Get all categories of a product.
+// @Description Get all categories of a product. Editors and admins can get the categories of unpublished products.
 // @Summary get product categories
 // @Tags Product
 // @Accept json
@@ -408,10 +724,10 @@ func GetProductCategories(c *fiber.Ctx) error {
 		})
 	}
 
-	// Check if product exists
+	// Hide products that are not published from the public
 	productRepo := repo.NewProductRepository(database.GetDB())
-	_, err = productRepo.GetByID(id)
-	if err != nil {
+	publishStatus, err := productRepo.GetPublishStatus(id)
+	if err != nil || (publishStatus != model.PublishStatusPublished && !canSeeUnpublished(c)) {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"msg": "product not found",
 		})
@@ -429,3 +745,182 @@ func GetProductCategories(c *fiber.Ctx) error {
 		"categories": dto.ToCategories(categories),
 	})
 }
+
+// GetProductsBatch func for looking up many products at once.
+// @Description Get up to 100 products by ID and/or SKU. Results follow the request order, IDs first, and contain an explicit entry for every ID or SKU that was not found.
+// @Summary batch product lookup
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param batch body model.ProductBatchInput true "Product IDs and SKUs"
+// @Success 200 {object} ProductBatchResponse
+// @Failure 400,500 {object} ErrorResponse "Error"
+// @Router /api/v1/products/batch [post]
+func GetProductsBatch(c *fiber.Ctx) error {
+	// Parse request body
+	input := &model.ProductBatchInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Validate input
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	requested := len(input.IDs) + len(input.SKUs)
+	if requested == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "at least one product ID or SKU is required",
+		})
+	}
+	if requested > model.MaxProductBatchSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": fmt.Sprintf("at most %d product IDs and SKUs can be requested at once", model.MaxProductBatchSize),
+		})
+	}
+
+	// Load all products with a constant number of queries
+	productRepo := repo.NewProductRepository(database.GetDB())
+	byID, err := productRepo.GetByIDs(input.IDs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	bySKU, err := productRepo.GetBySKUs(input.SKUs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	byID, bySKU = publishedOnly(byID), publishedOnly(bySKU)
+
+	productsByID := make(map[uuid.UUID]*dto.Product, len(byID))
+	for i := range byID {
+		productsByID[byID[i].ID] = dto.ToProduct(&byID[i])
+	}
+	productsBySKU := make(map[string]*dto.Product, len(bySKU))
+	for i := range bySKU {
+		productsBySKU[bySKU[i].SKU] = dto.ToProduct(&bySKU[i])
+	}
+
+	// Build results in request order
+	results := make([]dto.ProductBatchResult, 0, requested)
+	found := 0
+	for _, id := range input.IDs {
+		id := id
+		product, ok := productsByID[id]
+		if ok {
+			found++
+		}
+		results = append(results, dto.ProductBatchResult{ID: &id, Found: ok, Product: product})
+	}
+	for _, sku := range input.SKUs {
+		product, ok := productsBySKU[sku]
+		if ok {
+			found++
+		}
+		results = append(results, dto.ProductBatchResult{SKU: sku, Found: ok, Product: product})
+	}
+
+	return c.JSON(ProductBatchResponse{
+		Found:    found,
+		NotFound: len(results) - found,
+		Results:  results,
+	})
+}
+
+// CompareProducts func for comparing products side by side.
+// @Description Compare 2 to 4 products: an aligned attribute matrix with a differs flag per row, price and rating summary rows and the categories shared by all products.
+// @Summary compare products
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param ids query string true "Comma separated product IDs (UUID format), 2 to 4"
+// @Success 200 {object} dto.ProductComparison
+// @Failure 400,404,500 {object} ErrorResponse "Error"
+// @Router /api/v1/products/compare [get]
+func CompareProducts(c *fiber.Ctx) error {
+	// Parse product IDs, keeping the request order
+	var ids []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	for _, idStr := range strings.Split(c.Query("ids"), ",") {
+		idStr = strings.TrimSpace(idStr)
+		if idStr == "" {
+			continue
+		}
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid product ID format",
+			})
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) < 2 || len(ids) > 4 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "between 2 and 4 distinct product IDs are required",
+		})
+	}
+
+	// Get products
+	productRepo := repo.NewProductRepository(database.GetDB())
+	found, err := productRepo.GetByIDs(ids)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	byID := make(map[uuid.UUID]model.Product, len(found))
+	for _, product := range publishedOnly(found) {
+		byID[product.ID] = product
+	}
+
+	products := make([]model.Product, 0, len(ids))
+	for _, id := range ids {
+		product, ok := byID[id]
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"msg": fmt.Sprintf("product %s not found", id),
+			})
+		}
+		products = append(products, product)
+	}
+
+	// Get ratings
+	reviewRepo := repo.NewReviewRepository(database.GetDB())
+	ratings, err := reviewRepo.GetRatingStats(ids)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"comparison": dto.ToProductComparison(products, ratings),
+	})
+}
+
+// publishedOnly filters out the products that are not published
+func publishedOnly(products []model.Product) []model.Product {
+	published := make([]model.Product, 0, len(products))
+	for _, product := range products {
+		if product.PublishStatus == model.PublishStatusPublished {
+			published = append(published, product)
+		}
+	}
+	return published
+}
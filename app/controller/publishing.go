@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"fmt"
+	"golang-test1/app/dto"
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ProductPublishingResponse represents the publishing state of a product.
+type ProductPublishingResponse struct {
+	Product dto.Product         `json:"product"`
+	Draft   *model.ProductDraft `json:"draft,omitempty"`
+}
+
+// GetProductDraft func gets the pending draft of a published product.
+// @Description Get the pending edits of a published product, which are not live until published.
+// @Summary get the pending draft of a product
+// @Tags Publishing
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID format)"
+// @Success 200 {object} model.ProductDraft
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/products/{id}/draft [get]
+func GetProductDraft(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	publishingRepo := repo.NewPublishingRepository(database.GetDB())
+	draft, err := publishingRepo.GetDraft(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if draft == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product has no pending draft",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"draft": draft,
+	})
+}
+
+// UpdatePublishStatus func moves a product through the publishing workflow.
+// @Description Move a product through draft, in_review, scheduled, published and archived. Editors submit drafts for review and withdraw them, only admins schedule, publish, archive and restore. For a published product the move applies to its pending draft, except archiving.
+// @Summary change the publish status of a product
+// @Tags Publishing
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID format)"
+// @Param publish_status body model.PublishStatusInput true "Target publish status, publish_at is required for scheduled"
+// @Success 200 {object} ProductPublishingResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/products/{id}/publish-status [put]
+func UpdatePublishStatus(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	input := &model.PublishStatusInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	now := time.Now()
+	if input.Status == model.PublishStatusScheduled && (input.PublishAt == nil || !input.PublishAt.After(now)) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "publish_at in the future is required to schedule a product",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	product, err := productRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	publishingRepo := repo.NewPublishingRepository(database.GetDB())
+	draft, err := publishingRepo.GetDraft(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// A published product stays live while its pending draft moves through the workflow
+	onDraft := product.PublishStatus == model.PublishStatusPublished && input.Status != model.PublishStatusArchived
+	from := product.PublishStatus
+	if onDraft {
+		if draft == nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"msg": "product is already published and has no pending draft",
+			})
+		}
+		from = draft.Status
+	}
+
+	roles := model.PublishTransitionRoles(from, input.Status)
+	if roles == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": fmt.Sprintf("cannot move from %s to %s", from, input.Status),
+		})
+	}
+	if !slices.Contains(roles, OptionalRole(c)) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"msg": fmt.Sprintf("your role cannot move from %s to %s", from, input.Status),
+		})
+	}
+
+	var publishAt *time.Time
+	if input.Status == model.PublishStatusScheduled {
+		publishAt = input.PublishAt
+	}
+
+	switch {
+	case onDraft && input.Status == model.PublishStatusPublished:
+		err = publishingRepo.PublishDraft(id, now)
+	case onDraft:
+		err = publishingRepo.SetDraftStatus(id, input.Status, publishAt)
+	case input.Status == model.PublishStatusArchived:
+		err = publishingRepo.ArchiveProduct(id)
+	case input.Status == model.PublishStatusPublished:
+		err = publishingRepo.SetProductStatus(id, input.Status, nil, &now)
+	default:
+		err = publishingRepo.SetProductStatus(id, input.Status, publishAt, product.PublishedAt)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	// Return the resulting state
+	updatedProduct, err := productRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	draft, err = publishingRepo.GetDraft(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"product": dto.ToProduct(updatedProduct),
+		"draft":   draft,
+	})
+}
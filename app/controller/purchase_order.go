@@ -0,0 +1,548 @@
+package controller
+
+import (
+	"errors"
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderResponse represents a successful purchase order response.
+type PurchaseOrderResponse struct {
+	PurchaseOrder model.PurchaseOrder `json:"purchase_order"`
+}
+
+// PurchaseOrdersResponse represents a paginated purchase order list.
+type PurchaseOrdersResponse struct {
+	Page           int                   `json:"page"`
+	Size           int                   `json:"page_size"`
+	Total          int                   `json:"total"`
+	PurchaseOrders []model.PurchaseOrder `json:"purchase_orders"`
+}
+
+var purchaseOrderStatuses = map[string]bool{
+	model.PurchaseOrderDraft:             true,
+	model.PurchaseOrderSent:              true,
+	model.PurchaseOrderPartiallyReceived: true,
+	model.PurchaseOrderReceived:          true,
+}
+
+// checkPurchaseOrderInput checks that the supplier is active and that every line
+// refers to an existing product, once. It returns a message for the client when
+// the input is rejected.
+func checkPurchaseOrderInput(input *model.PurchaseOrderInput) (string, error) {
+	supplierRepo := repo.NewSupplierRepository(database.GetDB())
+	supplier, err := supplierRepo.GetByID(input.SupplierID)
+	if err != nil {
+		return "supplier not found", nil
+	}
+	if !supplier.IsActive {
+		return "supplier is not active", nil
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(input.Lines))
+	seen := make(map[uuid.UUID]bool, len(input.Lines))
+	for _, line := range input.Lines {
+		if seen[line.ProductID] {
+			return "product " + line.ProductID.String() + " appears on more than one line", nil
+		}
+		seen[line.ProductID] = true
+		productIDs = append(productIDs, line.ProductID)
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	products, err := productRepo.GetByIDs(productIDs)
+	if err != nil {
+		return "", err
+	}
+
+	found := make(map[uuid.UUID]bool, len(products))
+	for _, product := range products {
+		found[product.ID] = true
+	}
+	for _, productID := range productIDs {
+		if !found[productID] {
+			return "product " + productID.String() + " not found", nil
+		}
+	}
+
+	return "", nil
+}
+
+// CreatePurchaseOrder func for creating a new purchase order.
+// @Description Create a draft purchase order for a supplier. Lines without a unit cost take the cost of the supplier link, or else the cost price of the product.
+// @Summary create a new purchase order
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param purchase_order body model.PurchaseOrderInput true "Create new purchase order"
+// @Success 201 {object} PurchaseOrderResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/purchase-orders [post]
+func CreatePurchaseOrder(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	input := &model.PurchaseOrderInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	msg, err := checkPurchaseOrderInput(input)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	now := time.Now()
+	po := &model.PurchaseOrder{
+		ID:         uuid.New(),
+		SupplierID: input.SupplierID,
+		Status:     model.PurchaseOrderDraft,
+		ExpectedAt: input.ExpectedAt,
+		Notes:      input.Notes,
+		CreatedBy:  &userID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	poRepo := repo.NewPurchaseOrderRepository(database.GetDB())
+	if err := poRepo.Create(po, input.Lines); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	createdPO, err := poRepo.GetByID(po.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"purchase_order": createdPO,
+	})
+}
+
+// GetPurchaseOrder func gets a purchase order by ID.
+// @Description Get a purchase order with its lines.
+// @Summary get a purchase order
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID (UUID format)"
+// @Success 200 {object} PurchaseOrderResponse
+// @Failure 400,401,403,404 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/purchase-orders/{id} [get]
+func GetPurchaseOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid purchase order ID format",
+		})
+	}
+
+	poRepo := repo.NewPurchaseOrderRepository(database.GetDB())
+	po, err := poRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "purchase order not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"purchase_order": po,
+	})
+}
+
+// ListPurchaseOrders func lists purchase orders.
+// @Description List purchase orders, most recent first.
+// @Summary list purchase orders
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param supplier_id query string false "Supplier ID (UUID format)"
+// @Param status query string false "Status (draft, sent, partially_received, received)"
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Success 200 {object} PurchaseOrdersResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/purchase-orders [get]
+func ListPurchaseOrders(c *fiber.Ctx) error {
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	var supplierID *uuid.UUID
+	if supplierIDStr := c.Query("supplier_id"); supplierIDStr != "" {
+		id, err := uuid.Parse(supplierIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid supplier ID format",
+			})
+		}
+		supplierID = &id
+	}
+
+	status := c.Query("status")
+	if status != "" && !purchaseOrderStatuses[status] {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid purchase order status",
+		})
+	}
+
+	poRepo := repo.NewPurchaseOrderRepository(database.GetDB())
+	orders, total, err := poRepo.List(offset, pageSize, supplierID, status)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"page":            pageNo,
+		"page_size":       pageSize,
+		"total":           total,
+		"purchase_orders": orders,
+	})
+}
+
+// UpdatePurchaseOrder func updates a draft purchase order.
+// @Description Update a draft purchase order. The lines replace the existing ones.
+// @Summary update a purchase order
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID (UUID format)"
+// @Param purchase_order body model.PurchaseOrderInput true "Update purchase order"
+// @Success 200 {object} PurchaseOrderResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/purchase-orders/{id} [put]
+func UpdatePurchaseOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid purchase order ID format",
+		})
+	}
+
+	input := &model.PurchaseOrderInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	poRepo := repo.NewPurchaseOrderRepository(database.GetDB())
+	po, err := poRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "purchase order not found",
+		})
+	}
+
+	if po.Status != model.PurchaseOrderDraft {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "only draft purchase orders can be updated",
+		})
+	}
+
+	msg, err := checkPurchaseOrderInput(input)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	po.SupplierID = input.SupplierID
+	po.ExpectedAt = input.ExpectedAt
+	po.Notes = input.Notes
+
+	if err := poRepo.Update(po, input.Lines); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	updatedPO, err := poRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"purchase_order": updatedPO,
+	})
+}
+
+// DeletePurchaseOrder func deletes a draft purchase order.
+// @Description Delete a draft purchase order.
+// @Summary delete a purchase order
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID (UUID format)"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/purchase-orders/{id} [delete]
+func DeletePurchaseOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid purchase order ID format",
+		})
+	}
+
+	poRepo := repo.NewPurchaseOrderRepository(database.GetDB())
+	po, err := poRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "purchase order not found",
+		})
+	}
+
+	if po.Status != model.PurchaseOrderDraft {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "only draft purchase orders can be deleted",
+		})
+	}
+
+	if err := poRepo.Delete(id); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "purchase order deleted successfully",
+	})
+}
+
+// SendPurchaseOrder func marks a draft purchase order as sent to the supplier.
+// @Description Mark a draft purchase order as sent. Sent orders can no longer be changed and can receive goods.
+// @Summary send a purchase order
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID (UUID format)"
+// @Success 200 {object} PurchaseOrderResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/purchase-orders/{id}/send [post]
+func SendPurchaseOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid purchase order ID format",
+		})
+	}
+
+	poRepo := repo.NewPurchaseOrderRepository(database.GetDB())
+	po, err := poRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "purchase order not found",
+		})
+	}
+
+	if po.Status != model.PurchaseOrderDraft {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "purchase order has already been sent",
+		})
+	}
+
+	if len(po.Lines) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "purchase order has no lines",
+		})
+	}
+
+	if err := poRepo.MarkSent(id, time.Now()); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	sentPO, err := poRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"purchase_order": sentPO,
+	})
+}
+
+// ReceivePurchaseOrder func books a goods receipt against a purchase order.
+// @Description Record the goods received for a sent purchase order, in the given warehouse or else the default warehouse. Stock is increased and a purchase inventory movement is recorded for each line. Partial receipts are allowed; the order is received once every line is complete.
+// @Summary receive goods for a purchase order
+// @Tags PurchaseOrder
+// @Accept json
+// @Produce json
+// @Param id path string true "Purchase order ID (UUID format)"
+// @Param receipt body model.GoodsReceiptInput true "Goods receipt"
+// @Success 200 {object} PurchaseOrderResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/purchase-orders/{id}/receipts [post]
+func ReceivePurchaseOrder(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid purchase order ID format",
+		})
+	}
+
+	input := &model.GoodsReceiptInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	poRepo := repo.NewPurchaseOrderRepository(database.GetDB())
+	if _, err := poRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "purchase order not found",
+		})
+	}
+
+	if input.WarehouseID != nil {
+		warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+		warehouse, err := warehouseRepo.GetByID(*input.WarehouseID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "warehouse not found",
+			})
+		}
+		if !warehouse.IsActive {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "warehouse is not active",
+			})
+		}
+	}
+
+	if err := poRepo.Receive(id, *input, &userID); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrPurchaseOrderNotReceivable):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		case errors.Is(err, repo.ErrReceiptProductNotOrdered), errors.Is(err, repo.ErrReceiptExceedsOrdered):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	receivedPO, err := poRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"purchase_order": receivedPO,
+	})
+}
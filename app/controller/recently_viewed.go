@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/config"
+	"golang-test1/platform/database"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// GetRecentlyViewed returns the products recently viewed by the user
+// @Description Get the products the current user viewed recently, most recent first
+// @Summary get recently viewed products
+// @Tags RecentlyViewed
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.RecentlyViewedItem "Recently viewed products"
+// @Failure 401,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/me/recently-viewed [get]
+func GetRecentlyViewed(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	// Get recently viewed entries
+	recentlyViewedRepo := repo.NewRecentlyViewedRepository(database.GetDB())
+	entries, err := recentlyViewedRepo.GetByUserID(userID, config.WorkerCfg().RecentlyViewedLimit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to retrieve recently viewed products",
+		})
+	}
+
+	// Load the products with a single lookup
+	productIDs := make([]uuid.UUID, len(entries))
+	for i, entry := range entries {
+		productIDs[i] = entry.ProductID
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	products, err := productRepo.GetByIDs(productIDs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to retrieve recently viewed products",
+		})
+	}
+
+	products = publishedOnly(products)
+
+	productsByID := make(map[uuid.UUID]*model.Product, len(products))
+	for i := range products {
+		productsByID[products[i].ID] = &products[i]
+	}
+
+	items := make([]model.RecentlyViewedItem, 0, len(entries))
+	for _, entry := range entries {
+		product, ok := productsByID[entry.ProductID]
+		if !ok {
+			continue
+		}
+		entry.Product = product
+		items = append(items, entry)
+	}
+
+	return c.JSON(fiber.Map{
+		"count": len(items),
+		"items": items,
+	})
+}
+
+// RemoveRecentlyViewed removes a product from the user's recently viewed products
+// @Description Remove a single product from the current user's recently viewed products
+// @Summary remove recently viewed product
+// @Tags RecentlyViewed
+// @Accept json
+// @Produce json
+// @Param product_id path string true "Product ID"
+// @Success 200 {object} interface{} "Success"
+// @Failure 400,401,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/me/recently-viewed/{product_id} [delete]
+func RemoveRecentlyViewed(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	// Get product ID from path parameter
+	productID, err := uuid.Parse(c.Params("product_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	recentlyViewedRepo := repo.NewRecentlyViewedRepository(database.GetDB())
+	if err := recentlyViewedRepo.Remove(userID, productID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to remove product from recently viewed",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "product removed from recently viewed successfully",
+	})
+}
+
+// ClearRecentlyViewed clears the user's browsing history
+// @Description Remove all products from the current user's recently viewed products
+// @Summary clear recently viewed products
+// @Tags RecentlyViewed
+// @Accept json
+// @Produce json
+// @Success 200 {object} interface{} "Success"
+// @Failure 401,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/me/recently-viewed [delete]
+func ClearRecentlyViewed(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	recentlyViewedRepo := repo.NewRecentlyViewedRepository(database.GetDB())
+	if err := recentlyViewedRepo.Clear(userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to clear recently viewed products",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "recently viewed products cleared successfully",
+	})
+}
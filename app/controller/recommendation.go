@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/platform/database"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// getRecommendationLimit returns the limit query parameter, 10 by default and at most 50
+func getRecommendationLimit(c *fiber.Ctx) int {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		return 10
+	}
+	if limit > 50 {
+		return 50
+	}
+	return limit
+}
+
+// withProducts loads the products of the recommendations with a single lookup, keeping the ranking.
+// Products unpublished since the recommendations were read are left out.
+func withProducts(recommendations []model.Recommendation) ([]model.Recommendation, error) {
+	productIDs := make([]uuid.UUID, len(recommendations))
+	for i, recommendation := range recommendations {
+		productIDs[i] = recommendation.ProductID
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	products, err := productRepo.GetByIDs(productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	productsByID := make(map[uuid.UUID]*model.Product, len(products))
+	for i := range products {
+		productsByID[products[i].ID] = &products[i]
+	}
+
+	result := make([]model.Recommendation, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		product, ok := productsByID[recommendation.ProductID]
+		if !ok || product.PublishStatus != model.PublishStatusPublished {
+			continue
+		}
+		recommendation.Product = product
+		result = append(result, recommendation)
+	}
+
+	return result, nil
+}
+
+// GetProductRecommendations returns the products customers also wishlisted
+// @Description Get active, published products that customers who wishlisted or liked this product also wishlisted or liked. Products in the authenticated user's wishlist are left out. Only editors and admins get recommendations for unpublished products.
+// @Summary get product recommendations
+// @Tags Recommendation
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID format)"
+// @Param limit query integer false "Maximum number of recommendations (default 10, max 50)"
+// @Success 200 {array} model.Recommendation "Recommendations"
+// @Failure 400,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/products/{id}/recommendations [get]
+func GetProductRecommendations(c *fiber.Ctx) error {
+	productID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	// Hide products that are not published from the public
+	productRepo := repo.NewProductRepository(database.GetDB())
+	publishStatus, err := productRepo.GetPublishStatus(productID)
+	if err != nil || (publishStatus != model.PublishStatusPublished && !canSeeUnpublished(c)) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	// Leave out the authenticated user's wishlist
+	var userID *uuid.UUID
+	if id, ok := OptionalUserID(c); ok {
+		userID = &id
+	}
+
+	recommendationRepo := repo.NewRecommendationRepository(database.GetDB())
+	recommendations, err := recommendationRepo.GetSimilar(productID, userID, getRecommendationLimit(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to retrieve recommendations",
+		})
+	}
+
+	recommendations, err = withProducts(recommendations)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to retrieve recommendations",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"count":           len(recommendations),
+		"recommendations": recommendations,
+	})
+}
+
+// GetMyRecommendations returns personalised recommendations
+// @Description Get active products similar to the items of the current user's wishlist, excluding products already in it
+// @Summary get personalised recommendations
+// @Tags Recommendation
+// @Accept json
+// @Produce json
+// @Param limit query integer false "Maximum number of recommendations (default 10, max 50)"
+// @Success 200 {array} model.Recommendation "Recommendations"
+// @Failure 401,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/me/recommendations [get]
+func GetMyRecommendations(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	recommendationRepo := repo.NewRecommendationRepository(database.GetDB())
+	recommendations, err := recommendationRepo.GetForUser(userID, getRecommendationLimit(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to retrieve recommendations",
+		})
+	}
+
+	recommendations, err = withProducts(recommendations)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to retrieve recommendations",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"count":           len(recommendations),
+		"recommendations": recommendations,
+	})
+}
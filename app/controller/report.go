@@ -0,0 +1,362 @@
+package controller
+
+import (
+	"encoding/csv"
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/platform/database"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ProductMarginReportResponse represents the product margin report.
+type ProductMarginReportResponse struct {
+	Page     int                   `json:"page"`
+	Size     int                   `json:"page_size"`
+	Summary  model.MarginSummary   `json:"summary"`
+	Products []model.ProductMargin `json:"products"`
+}
+
+// CategoryMarginReportResponse represents the category margin report.
+type CategoryMarginReportResponse struct {
+	Categories []model.CategoryMargin `json:"categories"`
+}
+
+// StockReportResponse represents the stock report.
+type StockReportResponse struct {
+	Page    int                `json:"page"`
+	Size    int                `json:"page_size"`
+	Summary model.StockSummary `json:"summary"`
+	Levels  []model.StockLevel `json:"levels"`
+}
+
+// parseMarginReportFilter reads the margin report filters from the query string.
+// validSortFields lists the sort_by values of the report, the first one being the default.
+func parseMarginReportFilter(c *fiber.Ctx, validSortFields []string) (model.MarginReportFilter, string) {
+	filter := model.MarginReportFilter{
+		Status:        c.Query("status"),
+		BelowCostOnly: c.QueryBool("below_cost"),
+		SortBy:        validSortFields[0],
+		SortOrder:     "asc",
+	}
+
+	if c.Query("warehouse_id") != "" {
+		id, err := uuid.Parse(c.Query("warehouse_id"))
+		if err != nil {
+			return filter, "invalid warehouse ID format"
+		}
+		filter.WarehouseID = &id
+	}
+
+	if c.Query("category_id") != "" {
+		id, err := uuid.Parse(c.Query("category_id"))
+		if err != nil {
+			return filter, "invalid category ID format"
+		}
+		filter.CategoryID = &id
+	}
+
+	if c.Query("min_margin_percent") != "" {
+		val, err := strconv.ParseFloat(c.Query("min_margin_percent"), 64)
+		if err != nil {
+			return filter, "invalid min_margin_percent format"
+		}
+		filter.MinMarginPercent = &val
+	}
+	if c.Query("max_margin_percent") != "" {
+		val, err := strconv.ParseFloat(c.Query("max_margin_percent"), 64)
+		if err != nil {
+			return filter, "invalid max_margin_percent format"
+		}
+		filter.MaxMarginPercent = &val
+	}
+
+	for _, field := range validSortFields {
+		if c.Query("sort_by") == field {
+			filter.SortBy = field
+		}
+	}
+	if c.Query("sort_order") == "desc" {
+		filter.SortOrder = "desc"
+	}
+
+	return filter, ""
+}
+
+// formatFloat formats a CSV amount
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+// GetProductMarginReport func returns the margin of each product.
+// @Description Get the unit margin and margin % of each product with a cost price, at its effective price (the sale price when it is below the regular price). Products sold below cost are flagged. With format=csv, all matching products are exported as CSV.
+// @Summary get product margin report
+// @Tags Report
+// @Accept json
+// @Produce json,text/csv
+// @Param warehouse_id query string false "Only the stock of this warehouse (UUID format)"
+// @Param category_id query string false "Only products of this category (UUID format)"
+// @Param status query string false "Filter by status (active, inactive, out_of_stock)"
+// @Param below_cost query boolean false "Only products sold below cost"
+// @Param min_margin_percent query number false "Minimum margin %"
+// @Param max_margin_percent query number false "Maximum margin %"
+// @Param sort_by query string false "Sort field (margin_percent, unit_margin, stock_margin, effective_price, cost_price, name, sku)"
+// @Param sort_order query string false "Sort order (asc, desc)"
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Param format query string false "Response format (json, csv)"
+// @Success 200 {object} ProductMarginReportResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reports/margins/products [get]
+func GetProductMarginReport(c *fiber.Ctx) error {
+	filter, msg := parseMarginReportFilter(c, []string{
+		"margin_percent", "unit_margin", "stock_margin", "effective_price", "cost_price", "name", "sku",
+	})
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	// The CSV export is not paginated
+	csvExport := c.Query("format") == "csv"
+	if csvExport {
+		offset, pageSize = 0, 0
+	}
+
+	reportRepo := repo.NewReportRepository(database.GetDB())
+	margins, summary, err := reportRepo.GetProductMargins(filter, offset, pageSize)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	if csvExport {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Attachment("product-margins.csv")
+
+		writer := csv.NewWriter(c)
+		writer.Write([]string{
+			"product_id", "sku", "name", "status", "price", "effective_price", "cost_price",
+			"unit_margin", "margin_percent", "stock_quantity", "stock_margin", "below_cost",
+		})
+		for _, margin := range margins {
+			writer.Write([]string{
+				margin.ProductID.String(),
+				margin.SKU,
+				margin.Name,
+				margin.Status,
+				formatFloat(margin.Price),
+				formatFloat(margin.EffectivePrice),
+				formatFloat(margin.CostPrice),
+				formatFloat(margin.UnitMargin),
+				formatFloat(margin.MarginPercent),
+				strconv.Itoa(margin.StockQuantity),
+				formatFloat(margin.StockMargin),
+				strconv.FormatBool(margin.BelowCost),
+			})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	return c.JSON(fiber.Map{
+		"page":      pageNo,
+		"page_size": pageSize,
+		"summary":   summary,
+		"products":  margins,
+	})
+}
+
+// GetCategoryMarginReport func returns the margins aggregated per category.
+// @Description Get the product margins aggregated per category: average unit margin and margin %, their range, the margin of the stock and the number of products sold below cost. A product counts in each of its categories. With format=csv, the report is exported as CSV.
+// @Summary get category margin report
+// @Tags Report
+// @Accept json
+// @Produce json,text/csv
+// @Param warehouse_id query string false "Only the stock of this warehouse (UUID format)"
+// @Param category_id query string false "Only products of this category (UUID format)"
+// @Param status query string false "Filter by status (active, inactive, out_of_stock)"
+// @Param below_cost query boolean false "Only products sold below cost"
+// @Param min_margin_percent query number false "Minimum margin %"
+// @Param max_margin_percent query number false "Maximum margin %"
+// @Param sort_by query string false "Sort field (average_margin_percent, average_unit_margin, stock_margin, below_cost_count, product_count, name)"
+// @Param sort_order query string false "Sort order (asc, desc)"
+// @Param format query string false "Response format (json, csv)"
+// @Success 200 {object} CategoryMarginReportResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reports/margins/categories [get]
+func GetCategoryMarginReport(c *fiber.Ctx) error {
+	filter, msg := parseMarginReportFilter(c, []string{
+		"average_margin_percent", "average_unit_margin", "stock_margin", "below_cost_count", "product_count", "name",
+	})
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	reportRepo := repo.NewReportRepository(database.GetDB())
+	margins, err := reportRepo.GetCategoryMargins(filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	if c.Query("format") == "csv" {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Attachment("category-margins.csv")
+
+		writer := csv.NewWriter(c)
+		writer.Write([]string{
+			"category_id", "name", "product_count", "average_unit_margin", "average_margin_percent",
+			"min_margin_percent", "max_margin_percent", "stock_margin", "below_cost_count",
+		})
+		for _, margin := range margins {
+			writer.Write([]string{
+				margin.CategoryID.String(),
+				margin.Name,
+				strconv.Itoa(margin.ProductCount),
+				formatFloat(margin.AverageUnitMargin),
+				formatFloat(margin.AverageMarginPercent),
+				formatFloat(margin.MinMarginPercent),
+				formatFloat(margin.MaxMarginPercent),
+				formatFloat(margin.StockMargin),
+				strconv.Itoa(margin.BelowCostCount),
+			})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	return c.JSON(fiber.Map{
+		"categories": margins,
+	})
+}
+
+// GetStockReport func returns the stock levels per warehouse.
+// @Description Get the stock of each product in each warehouse, valued at cost price, with totals. Filter on a warehouse to report its stock only, and on a maximum quantity to find low stock. With format=csv, all matching rows are exported as CSV.
+// @Summary get stock report
+// @Tags Report
+// @Accept json
+// @Produce json,text/csv
+// @Param warehouse_id query string false "Only this warehouse (UUID format)"
+// @Param category_id query string false "Only products of this category (UUID format)"
+// @Param status query string false "Filter by status (active, inactive, out_of_stock)"
+// @Param search query string false "Search term for SKU or name"
+// @Param max_quantity query integer false "Only stock levels at or below this quantity"
+// @Param sort_by query string false "Sort field (sku, name, quantity, stock_value, warehouse_code)"
+// @Param sort_order query string false "Sort order (asc, desc)"
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Param format query string false "Response format (json, csv)"
+// @Success 200 {object} StockReportResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reports/stock [get]
+func GetStockReport(c *fiber.Ctx) error {
+	filter := model.StockReportFilter{
+		Status:    c.Query("status"),
+		Search:    c.Query("search"),
+		SortBy:    "sku",
+		SortOrder: "asc",
+	}
+
+	if c.Query("warehouse_id") != "" {
+		id, err := uuid.Parse(c.Query("warehouse_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid warehouse ID format",
+			})
+		}
+		filter.WarehouseID = &id
+	}
+
+	if c.Query("category_id") != "" {
+		id, err := uuid.Parse(c.Query("category_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid category ID format",
+			})
+		}
+		filter.CategoryID = &id
+	}
+
+	if c.Query("max_quantity") != "" {
+		val, err := strconv.Atoi(c.Query("max_quantity"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid max_quantity format",
+			})
+		}
+		filter.MaxQuantity = &val
+	}
+
+	for _, field := range []string{"sku", "name", "quantity", "stock_value", "warehouse_code"} {
+		if c.Query("sort_by") == field {
+			filter.SortBy = field
+		}
+	}
+	if c.Query("sort_order") == "desc" {
+		filter.SortOrder = "desc"
+	}
+
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	// The CSV export is not paginated
+	csvExport := c.Query("format") == "csv"
+	if csvExport {
+		offset, pageSize = 0, 0
+	}
+
+	reportRepo := repo.NewReportRepository(database.GetDB())
+	levels, summary, err := reportRepo.GetStockLevels(filter, offset, pageSize)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	if csvExport {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Attachment("stock.csv")
+
+		writer := csv.NewWriter(c)
+		writer.Write([]string{
+			"warehouse_id", "warehouse_code", "product_id", "sku", "name", "status",
+			"quantity", "cost_price", "stock_value",
+		})
+		for _, level := range levels {
+			writer.Write([]string{
+				level.WarehouseID.String(),
+				level.WarehouseCode,
+				level.ProductID.String(),
+				level.SKU,
+				level.Name,
+				level.Status,
+				strconv.Itoa(level.Quantity),
+				formatFloat(level.CostPrice),
+				formatFloat(level.StockValue),
+			})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	return c.JSON(fiber.Map{
+		"page":      pageNo,
+		"page_size": pageSize,
+		"summary":   summary,
+		"levels":    levels,
+	})
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"database/sql"
+	"errors"
 	"time"
 
 	"golang-test1/app/model"
@@ -14,7 +16,7 @@ import (
 )
 
 // CreateReview creates a new product review
-// @Description Add a new review for a product
+// @Description Add a new review for a product. The review is pending until an admin approves it.
 // @Summary create product review
 // @Tags Review
 // @Accept json
@@ -86,6 +88,7 @@ func CreateReview(c *fiber.Ctx) error {
 		Comment:            input.Comment,
 		IsVerifiedPurchase: false, // This could be determined by checking order history
 		HelpfulVotes:       0,
+		ModerationStatus:   model.ReviewStatusPending,
 		CreatedAt:          now,
 		UpdatedAt:          now,
 	}
@@ -107,13 +110,13 @@ func CreateReview(c *fiber.Ctx) error {
 	}
 
 	return c.JSON(fiber.Map{
-		"msg":    "review created successfully",
+		"msg":    "review created successfully, it is pending moderation",
 		"review": createdReview,
 	})
 }
 
 // GetReview retrieves a review by ID
-// @Description Get a review by its ID
+// @Description Get a review by its ID. Reviews that are not approved are only visible to their author and admins.
 // @Summary get review by ID
 // @Tags Review
 // @Accept json
@@ -142,18 +145,29 @@ func GetReview(c *fiber.Ctx) error {
 		})
 	}
 
+	// Reviews waiting for or failing moderation are not public
+	if review.ModerationStatus != model.ReviewStatusApproved && OptionalRole(c) != "admin" {
+		if userID, ok := OptionalUserID(c); !ok || userID != review.UserID {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"msg": "review not found",
+			})
+		}
+	}
+
 	return c.JSON(fiber.Map{
 		"review": review,
 	})
 }
 
 // GetProductReviews retrieves all reviews for a product
-// @Description Get all reviews for a specific product
+// @Description Get the approved reviews of a specific product
 // @Summary get product reviews
 // @Tags Review
 // @Accept json
 // @Produce json
 // @Param product_id path string true "Product ID"
+// @Param sort query string false "Sort order: newest (default) or most_helpful"
+// @Param with_photos query boolean false "Only reviews with photos"
 // @Success 200 {array} model.Review "Product reviews"
 // @Failure 400,404,500 {object} ErrorResponse "Error"
 // @Router /api/v1/products/{product_id}/reviews [get]
@@ -175,9 +189,16 @@ func GetProductReviews(c *fiber.Ctx) error {
 		})
 	}
 
+	sort := c.Query("sort", model.ReviewSortNewest)
+	if sort != model.ReviewSortMostHelpful {
+		sort = model.ReviewSortNewest
+	}
+
+	withPhotos := c.QueryBool("with_photos", false)
+
 	// Get reviews for the product
 	reviewRepo := repo.NewReviewRepository(database.GetDB())
-	reviews, err := reviewRepo.GetByProductID(productID)
+	reviews, err := reviewRepo.GetByProductID(productID, sort, withPhotos)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"msg": "failed to retrieve product reviews",
@@ -190,8 +211,234 @@ func GetProductReviews(c *fiber.Ctx) error {
 	})
 }
 
+// RatingSummaryResponse represents the rating summary of a product.
+type RatingSummaryResponse struct {
+	RatingSummary model.RatingSummary `json:"rating_summary"`
+}
+
+// GetProductRatingSummary retrieves the rating summary of a product
+// @Description Get the average rating, review count and number of reviews per star of a product, counting approved reviews
+// @Summary get product rating summary
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param product_id path string true "Product ID"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Param If-Modified-Since header string false "Last-Modified date of a cached copy"
+// @Success 200 {object} RatingSummaryResponse
+// @Success 304 "Not Modified"
+// @Failure 400,404,500 {object} ErrorResponse "Error"
+// @Router /api/v1/products/{product_id}/rating-summary [get]
+func GetProductRatingSummary(c *fiber.Ctx) error {
+	productID, err := uuid.Parse(c.Params("product_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+
+	// Hide products that are not published from the public
+	publishStatus, err := productRepo.GetPublishStatus(productID)
+	if err != nil || (publishStatus != model.PublishStatusPublished && !canSeeUnpublished(c)) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	// The product version follows its rating summary
+	version, err := productRepo.GetVersion(productID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+	if setCacheValidators(c, "rating-summary", version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	reviewRepo := repo.NewReviewRepository(database.GetDB())
+	summary, err := reviewRepo.GetRatingSummary(productID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to retrieve rating summary",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"rating_summary": summary,
+	})
+}
+
+// ReviewVoteResponse represents the vote counters of a review.
+type ReviewVoteResponse struct {
+	Msg  string           `json:"msg"`
+	Vote model.ReviewVote `json:"vote"`
+}
+
+// VoteReview records whether the current user found a review helpful
+// @Description Vote on a review as helpful, or as not helpful with {"helpful": false}. A user has one vote per review; voting again replaces it. Users cannot vote on their own reviews.
+// @Summary vote on a review
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "Review ID"
+// @Param vote body model.ReviewVoteInput false "Vote, helpful by default"
+// @Success 200 {object} ReviewVoteResponse "Vote counters"
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/{id}/helpful [post]
+func VoteReview(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	// Parse review ID from URL parameter
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid review ID format",
+		})
+	}
+
+	// Parse request body, which may be empty
+	var input model.ReviewVoteInput
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid request body",
+			})
+		}
+	}
+	helpful := input.Helpful == nil || *input.Helpful
+
+	// Get the review
+	reviewRepo := repo.NewReviewRepository(database.GetDB())
+	review, err := reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "review not found",
+		})
+	}
+
+	// Only approved reviews are public
+	if review.ModerationStatus != model.ReviewStatusApproved {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "review not found",
+		})
+	}
+
+	// Authors cannot vote on their own reviews
+	if review.UserID == userID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"msg": "you cannot vote on your own review",
+		})
+	}
+
+	vote, err := reviewRepo.Vote(reviewID, userID, helpful)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"msg": "review not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to record vote",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg":  "vote recorded successfully",
+		"vote": vote,
+	})
+}
+
+// UnvoteReview removes the vote of the current user on a review
+// @Description Remove the helpful or not helpful vote of the current user on a review
+// @Summary remove vote on a review
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "Review ID"
+// @Success 200 {object} ReviewVoteResponse "Vote counters"
+// @Failure 400,401,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/{id}/helpful [delete]
+func UnvoteReview(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	// Parse review ID from URL parameter
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid review ID format",
+		})
+	}
+
+	reviewRepo := repo.NewReviewRepository(database.GetDB())
+	vote, err := reviewRepo.DeleteVote(reviewID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"msg": "vote not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to remove vote",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg":  "vote removed successfully",
+		"vote": vote,
+	})
+}
+
 // GetUserReviews retrieves all reviews by a user
-// @Description Get all reviews written by the current user
+// @Description Get all reviews written by the current user, including those pending or rejected by moderation
 // @Summary get user reviews
 // @Tags Review
 // @Accept json
@@ -241,7 +488,7 @@ func GetUserReviews(c *fiber.Ctx) error {
 }
 
 // UpdateReview updates an existing review
-// @Description Update an existing review
+// @Description Update an existing review. The edited review is pending moderation again.
 // @Summary update review
 // @Tags Review
 // @Accept json
@@ -323,6 +570,7 @@ func UpdateReview(c *fiber.Ctx) error {
 	existingReview.Rating = input.Rating
 	existingReview.Title = input.Title
 	existingReview.Comment = input.Comment
+	existingReview.ModerationStatus = model.ReviewStatusPending
 	existingReview.UpdatedAt = time.Now()
 
 	// Save to database
@@ -421,7 +669,7 @@ func DeleteReview(c *fiber.Ctx) error {
 }
 
 // ListReviews gets all reviews with pagination
-// @Description Get all reviews with pagination
+// @Description Get all approved reviews with pagination
 // @Summary list all reviews
 // @Tags Review
 // @Accept json
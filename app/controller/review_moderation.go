@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ReviewModerationQueueResponse represents a page of the moderation queue.
+type ReviewModerationQueueResponse struct {
+	Page    int            `json:"page"`
+	Size    int            `json:"page_size"`
+	Total   int            `json:"total"`
+	Reviews []model.Review `json:"reviews"`
+}
+
+// ReviewModerationResponse represents the outcome of a bulk moderation.
+type ReviewModerationResponse struct {
+	Msg        string                 `json:"msg"`
+	Moderation model.ReviewModeration `json:"moderation"`
+}
+
+// GetReviewModerationQueue func lists the reviews to moderate.
+// @Description List reviews by moderation status, pending by default, oldest first.
+// @Summary get the review moderation queue
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param status query string false "Moderation status (pending, approved, rejected, all)"
+// @Param product_id query string false "Filter by product ID"
+// @Param user_id query string false "Filter by author ID"
+// @Param rating query integer false "Filter by rating (1-5)"
+// @Param created_after query string false "Filter by creation date (RFC3339 format)"
+// @Param created_before query string false "Filter by creation date (RFC3339 format)"
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Success 200 {object} ReviewModerationQueueResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/moderation [get]
+func GetReviewModerationQueue(c *fiber.Ctx) error {
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	filter := model.ReviewModerationFilter{}
+
+	switch status := c.Query("status", model.ReviewStatusPending); status {
+	case model.ReviewStatusPending, model.ReviewStatusApproved, model.ReviewStatusRejected:
+		filter.Status = status
+	case "all":
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid status, use pending, approved, rejected or all",
+		})
+	}
+
+	if c.Query("product_id") != "" {
+		id, err := uuid.Parse(c.Query("product_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid product ID format",
+			})
+		}
+		filter.ProductID = &id
+	}
+
+	if c.Query("user_id") != "" {
+		id, err := uuid.Parse(c.Query("user_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid user ID format",
+			})
+		}
+		filter.UserID = &id
+	}
+
+	if c.Query("rating") != "" {
+		rating, err := strconv.Atoi(c.Query("rating"))
+		if err != nil || rating < 1 || rating > 5 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid rating, use 1 to 5",
+			})
+		}
+		filter.Rating = &rating
+	}
+
+	if c.Query("created_after") != "" {
+		t, err := time.Parse(time.RFC3339, c.Query("created_after"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid created_after format, use RFC3339",
+			})
+		}
+		filter.CreatedAfter = &t
+	}
+	if c.Query("created_before") != "" {
+		t, err := time.Parse(time.RFC3339, c.Query("created_before"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid created_before format, use RFC3339",
+			})
+		}
+		filter.CreatedBefore = &t
+	}
+
+	reviewRepo := repo.NewReviewRepository(database.GetDB())
+	reviews, total, err := reviewRepo.ListForModeration(filter, offset, pageSize)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"page":      pageNo,
+		"page_size": pageSize,
+		"total":     total,
+		"reviews":   reviews,
+	})
+}
+
+// ModerateReviews func approves or rejects reviews in bulk.
+// @Description Approve or reject up to 100 reviews. A rejection needs a reason, shown to the author. Reviews that do not exist are listed in not_found.
+// @Summary moderate reviews
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param moderation body model.ReviewModerationInput true "Reviews and decision"
+// @Success 200 {object} ReviewModerationResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/moderation [put]
+func ModerateReviews(c *fiber.Ctx) error {
+	input := &model.ReviewModerationInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	var moderatedBy *uuid.UUID
+	if userID, ok := OptionalUserID(c); ok {
+		moderatedBy = &userID
+	}
+
+	reviewRepo := repo.NewReviewRepository(database.GetDB())
+	updated, err := reviewRepo.Moderate(input.ReviewIDs, input.Status, input.Reason, moderatedBy, time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	found := make(map[uuid.UUID]bool, len(updated))
+	for _, id := range updated {
+		found[id] = true
+	}
+	notFound := []uuid.UUID{}
+	for _, id := range input.ReviewIDs {
+		if !found[id] {
+			notFound = append(notFound, id)
+			found[id] = true
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "reviews moderated successfully",
+		"moderation": model.ReviewModeration{
+			Status:   input.Status,
+			Updated:  updated,
+			NotFound: notFound,
+		},
+	})
+}
@@ -0,0 +1,316 @@
+package controller
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/config"
+	"golang-test1/platform/database"
+	"golang-test1/platform/logger"
+	"golang-test1/platform/storage"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// ReviewResponse represents a review with a message.
+type ReviewResponse struct {
+	Msg    string       `json:"msg"`
+	Review model.Review `json:"review"`
+}
+
+// errReviewPhotoCount is returned by readReviewPhotos for photos past the count
+var errReviewPhotoCount = errors.New("too many photos")
+
+// reviewPhotoUpload is a photo read from an upload request
+type reviewPhotoUpload struct {
+	contentType string
+	content     []byte
+}
+
+// readReviewPhotos reads the parts of a multipart upload one at a time, rejecting the
+// upload at the first part that is not a photo, is past maxCount or is larger than
+// maxSize, before reading the rest of the body. Errors are meant for the client.
+func readReviewPhotos(c *fiber.Ctx, maxCount int, maxSize int64) ([]reviewPhotoUpload, error) {
+	boundary := string(c.Request().Header.MultipartFormBoundary())
+	if boundary == "" {
+		return nil, errors.New("no photos found, upload them in the photos field")
+	}
+
+	var uploads []reviewPhotoUpload
+	reader := multipart.NewReader(bytes.NewReader(c.Body()), boundary)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("invalid multipart form data")
+		}
+		if part.FormName() != "photos" || part.FileName() == "" {
+			return nil, fmt.Errorf("unexpected form field %s, upload photos in the photos field", part.FormName())
+		}
+		if len(uploads) >= maxCount {
+			return nil, errReviewPhotoCount
+		}
+
+		// Read one byte past the limit to tell a photo that is too large
+		content, err := io.ReadAll(io.LimitReader(part, maxSize+1))
+		if err != nil {
+			return nil, errors.New("failed to read photo " + part.FileName())
+		}
+		if int64(len(content)) > maxSize {
+			return nil, fmt.Errorf("photo %s is larger than %d MB", part.FileName(), maxSize>>20)
+		}
+
+		contentType := http.DetectContentType(content)
+		if _, ok := model.ReviewPhotoTypes[contentType]; !ok {
+			return nil, fmt.Errorf("photo %s is not a JPEG, PNG or WebP image", part.FileName())
+		}
+
+		uploads = append(uploads, reviewPhotoUpload{
+			contentType: contentType,
+			content:     content,
+		})
+	}
+
+	if len(uploads) == 0 {
+		return nil, errors.New("no photos found, upload them in the photos field")
+	}
+	return uploads, nil
+}
+
+// deleteReviewPhotos removes stored photo files, logging the files that could not be removed
+func deleteReviewPhotos(photos []model.ReviewPhoto) {
+	for _, photo := range photos {
+		if err := storage.GetStorage().Delete(photo.StorageKey); err != nil {
+			logger.GetLogger().Errorf("failed to delete review photo %s. error: %v", photo.StorageKey, err)
+		}
+	}
+}
+
+// UploadReviewPhotos attaches photos to a review
+// @Description Upload JPEG, PNG or WebP photos to your own review as multipart form data in the photos field. A review holds at most REVIEW_PHOTO_MAX_COUNT photos of at most REVIEW_PHOTO_MAX_SIZE_MB each. The review is pending moderation again.
+// @Summary upload review photos
+// @Tags Review
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Review ID"
+// @Param photos formData file true "Photos"
+// @Success 200 {object} ReviewResponse "Review with its photos"
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/{id}/photos [post]
+func UploadReviewPhotos(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	// Parse review ID from URL parameter
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid review ID format",
+		})
+	}
+
+	// Get the existing review
+	reviewRepo := repo.NewReviewRepository(database.GetDB())
+	review, err := reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "review not found",
+		})
+	}
+
+	// Verify the user is the owner of the review
+	if review.UserID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"msg": "you can only add photos to your own reviews",
+		})
+	}
+
+	// Read the uploaded photos, up to the photos the review still has room for
+	storageCfg := config.StorageCfg()
+	uploads, err := readReviewPhotos(c, storageCfg.ReviewPhotoMaxCount-len(review.Photos), storageCfg.ReviewPhotoMaxSize)
+	if err != nil {
+		msg := err.Error()
+		if errors.Is(err, errReviewPhotoCount) {
+			msg = fmt.Sprintf("a review holds at most %d photos", storageCfg.ReviewPhotoMaxCount)
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	now := time.Now()
+	photos := make([]model.ReviewPhoto, len(uploads))
+	for i, upload := range uploads {
+		id := uuid.New()
+		photos[i] = model.ReviewPhoto{
+			ID:          id,
+			ReviewID:    reviewID,
+			StorageKey:  fmt.Sprintf("reviews/%s/%s%s", reviewID, id, model.ReviewPhotoTypes[upload.contentType]),
+			ContentType: upload.contentType,
+			SizeBytes:   len(upload.content),
+			CreatedAt:   now,
+		}
+	}
+
+	// Store the files, then record them, removing the files again on failure
+	for i, upload := range uploads {
+		if err := storage.GetStorage().Save(photos[i].StorageKey, bytes.NewReader(upload.content)); err != nil {
+			deleteReviewPhotos(photos[:i+1])
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"msg": "failed to store photos",
+			})
+		}
+	}
+
+	if err := reviewRepo.AddPhotos(reviewID, photos, storageCfg.ReviewPhotoMaxCount); err != nil {
+		deleteReviewPhotos(photos)
+		if errors.Is(err, repo.ErrTooManyReviewPhotos) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"msg": "review not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to add photos",
+		})
+	}
+
+	// Get the review with all its photos
+	updatedReview, err := reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "photos added but failed to retrieve the review",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg":    "photos added successfully, the review is pending moderation",
+		"review": updatedReview,
+	})
+}
+
+// DeleteReviewPhoto removes a photo from a review
+// @Description Remove a photo from a review, by its author or an admin
+// @Summary delete review photo
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "Review ID"
+// @Param photo_id path string true "Photo ID"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/{id}/photos/{photo_id} [delete]
+func DeleteReviewPhoto(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	// Parse IDs from URL parameters
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid review ID format",
+		})
+	}
+
+	photoID, err := uuid.Parse(c.Params("photo_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid photo ID format",
+		})
+	}
+
+	// Get the existing review
+	reviewRepo := repo.NewReviewRepository(database.GetDB())
+	review, err := reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "review not found",
+		})
+	}
+
+	// Verify the user is the owner of the review (or admin)
+	if review.UserID != userID && OptionalRole(c) != "admin" {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"msg": "you can only delete photos of your own reviews",
+		})
+	}
+
+	photo, err := reviewRepo.DeletePhoto(reviewID, photoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"msg": "photo not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to delete photo",
+		})
+	}
+
+	// The photo is gone from the review, a file left behind is only logged
+	deleteReviewPhotos([]model.ReviewPhoto{*photo})
+
+	return c.JSON(fiber.Map{
+		"msg": "photo deleted successfully",
+	})
+}
@@ -2,6 +2,8 @@ package controller
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"golang-test1/platform/logger"
 	"strconv"
 )
@@ -42,3 +44,52 @@ func GetPagination(c *fiber.Ctx) (pageNo, pageSize int) {
 
 	return pageNo, pageSize
 }
+
+// OptionalUserID returns the ID of the authenticated user on routes using
+// middleware.JWTOptional, and false for anonymous requests.
+func OptionalUserID(c *fiber.Ctx) (uuid.UUID, bool) {
+	user, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	claims, ok := user.Claims.(jwt.MapClaims)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	userIDStr, ok := claims["user_id"].(string)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// OptionalRole returns the role of the authenticated user, or an empty string for anonymous requests
+func OptionalRole(c *fiber.Ctx) string {
+	user, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return ""
+	}
+
+	claims, ok := user.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+
+	role, _ := claims["role"].(string)
+	return role
+}
+
+// canSeeUnpublished reports whether the request comes from an editor or admin,
+// who also see products that are not published
+func canSeeUnpublished(c *fiber.Ctx) bool {
+	role := OptionalRole(c)
+	return role == "editor" || role == "admin"
+}
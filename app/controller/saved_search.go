@@ -0,0 +1,354 @@
+package controller
+
+import (
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// SavedSearchResponse represents a successful saved search response.
+type SavedSearchResponse struct {
+	SavedSearch model.SavedSearch `json:"saved_search"`
+}
+
+// checkSavedSearchFilters returns why the filters are inconsistent, or an empty string
+func checkSavedSearchFilters(filters model.SavedSearchFilters) string {
+	if filters.MinPrice != nil && filters.MaxPrice != nil && *filters.MinPrice > *filters.MaxPrice {
+		return "min_price must not be greater than max_price"
+	}
+	if filters.MinStock != nil && filters.MaxStock != nil && *filters.MinStock > *filters.MaxStock {
+		return "min_stock must not be greater than max_stock"
+	}
+	return ""
+}
+
+// GetSavedSearches returns the saved searches of the user
+// @Description Get the saved searches of the current user, ordered by name
+// @Summary get saved searches
+// @Tags SavedSearch
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.SavedSearch "Saved searches"
+// @Failure 401,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/me/saved-searches [get]
+func GetSavedSearches(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	savedSearchRepo := repo.NewSavedSearchRepository(database.GetDB())
+	savedSearches, err := savedSearchRepo.GetByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": "failed to retrieve saved searches",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"saved_searches": savedSearches,
+	})
+}
+
+// CreateSavedSearch saves a named product filter set for the user
+// @Description Save a named set of product list filters. With notify, the user is notified when new active products match the filters.
+// @Summary create a saved search
+// @Tags SavedSearch
+// @Accept json
+// @Produce json
+// @Param saved_search body model.SavedSearchInput true "Saved search"
+// @Success 201 {object} SavedSearchResponse
+// @Failure 400,401,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/me/saved-searches [post]
+func CreateSavedSearch(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	input := &model.SavedSearchInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	if strings.TrimSpace(input.Name) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "name must not be blank",
+		})
+	}
+
+	if msg := checkSavedSearchFilters(input.Filters); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	name := strings.TrimSpace(input.Name)
+	savedSearchRepo := repo.NewSavedSearchRepository(database.GetDB())
+
+	exists, err := savedSearchRepo.ExistsByName(userID, name, nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if exists {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "a saved search with this name already exists",
+		})
+	}
+
+	now := time.Now()
+	savedSearch := &model.SavedSearch{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Name:          name,
+		Filters:       input.Filters,
+		Notify:        input.Notify,
+		LastCheckedAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := savedSearchRepo.Create(savedSearch); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"saved_search": savedSearch,
+	})
+}
+
+// UpdateSavedSearch updates a saved search of the user
+// @Description Rename a saved search, change its filters or opt in or out of new-match notifications. Only products created after opting in are notified.
+// @Summary update a saved search
+// @Tags SavedSearch
+// @Accept json
+// @Produce json
+// @Param id path string true "Saved search ID (UUID format)"
+// @Param saved_search body model.SavedSearchInput true "Saved search"
+// @Success 200 {object} SavedSearchResponse
+// @Failure 400,401,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/me/saved-searches/{id} [put]
+func UpdateSavedSearch(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid saved search ID format",
+		})
+	}
+
+	savedSearchRepo := repo.NewSavedSearchRepository(database.GetDB())
+	savedSearch, err := savedSearchRepo.GetByID(userID, id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "saved search not found",
+		})
+	}
+
+	input := &model.SavedSearchInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	if strings.TrimSpace(input.Name) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "name must not be blank",
+		})
+	}
+
+	if msg := checkSavedSearchFilters(input.Filters); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	name := strings.TrimSpace(input.Name)
+	exists, err := savedSearchRepo.ExistsByName(userID, name, &id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if exists {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "a saved search with this name already exists",
+		})
+	}
+
+	// Opting in starts matching from now, not from the last check
+	if input.Notify && !savedSearch.Notify {
+		savedSearch.LastCheckedAt = time.Now()
+	}
+
+	savedSearch.Name = name
+	savedSearch.Filters = input.Filters
+	savedSearch.Notify = input.Notify
+
+	if err := savedSearchRepo.Update(savedSearch); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"saved_search": savedSearch,
+	})
+}
+
+// DeleteSavedSearch deletes a saved search of the user
+// @Description Delete a saved search of the current user
+// @Summary delete a saved search
+// @Tags SavedSearch
+// @Accept json
+// @Produce json
+// @Param id path string true "Saved search ID (UUID format)"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/me/saved-searches/{id} [delete]
+func DeleteSavedSearch(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid saved search ID format",
+		})
+	}
+
+	savedSearchRepo := repo.NewSavedSearchRepository(database.GetDB())
+	if _, err := savedSearchRepo.GetByID(userID, id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "saved search not found",
+		})
+	}
+
+	if err := savedSearchRepo.Delete(userID, id); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "saved search deleted successfully",
+	})
+}
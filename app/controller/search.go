@@ -0,0 +1,418 @@
+package controller
+
+import (
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// SuggestResponse represents the search-as-you-type suggestions.
+type SuggestResponse struct {
+	Query      string             `json:"query"`
+	Products   []model.Suggestion `json:"products"`
+	SKUs       []model.Suggestion `json:"skus"`
+	Categories []model.Suggestion `json:"categories"`
+}
+
+// SearchSynonymResponse represents a successful synonym group response.
+type SearchSynonymResponse struct {
+	Synonym model.SearchSynonym `json:"synonym"`
+}
+
+// SearchReportResponse represents the search analytics report.
+type SearchReportResponse struct {
+	From    time.Time               `json:"from"`
+	To      time.Time               `json:"to"`
+	Page    int                     `json:"page"`
+	Size    int                     `json:"page_size"`
+	Total   int                     `json:"total"`
+	Queries []model.SearchQueryStat `json:"queries"`
+}
+
+// SearchSuggest func returns search-as-you-type suggestions.
+// @Description Get product names, SKUs and category names matching a prefix, ranked by popularity.
+// @Summary search suggestions
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param q query string true "Prefix typed by the user"
+// @Param limit query integer false "Maximum number of suggestions per group (default 5, max 10)"
+// @Success 200 {object} SuggestResponse
+// @Failure 400,500 {object} ErrorResponse "Error"
+// @Router /api/v1/search/suggest [get]
+func SearchSuggest(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "query parameter q is required",
+		})
+	}
+	if utf8.RuneCountInString(query) > 100 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "query parameter q is too long",
+		})
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		limit = 5
+	}
+	if limit > 10 {
+		limit = 10
+	}
+
+	searchRepo := repo.NewSearchRepository(database.GetDB())
+
+	products, err := searchRepo.SuggestProducts(query, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	skus, err := searchRepo.SuggestSKUs(query, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	categories, err := searchRepo.SuggestCategories(query, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"query":      query,
+		"products":   products,
+		"skus":       skus,
+		"categories": categories,
+	})
+}
+
+// normalizeSynonymTerms lower-cases and trims the terms of a synonym group and drops duplicates
+func normalizeSynonymTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	normalized := make([]string, 0, len(terms))
+	for _, term := range terms {
+		term = repo.NormalizeSearchTerm(term)
+		if term == "" || seen[term] {
+			continue
+		}
+		seen[term] = true
+		normalized = append(normalized, term)
+	}
+	return normalized
+}
+
+// ListSearchSynonyms func lists the synonym groups.
+// @Description Get all search synonym groups.
+// @Summary list search synonyms
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.SearchSynonym
+// @Failure 401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/search/synonyms [get]
+func ListSearchSynonyms(c *fiber.Ctx) error {
+	searchRepo := repo.NewSearchRepository(database.GetDB())
+
+	synonyms, err := searchRepo.ListSynonyms()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"synonyms": synonyms,
+	})
+}
+
+// CreateSearchSynonym func creates a synonym group.
+// @Description Create a group of terms that match each other in product search.
+// @Summary create a search synonym group
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param synonym body model.SearchSynonymInput true "Synonym group"
+// @Success 201 {object} SearchSynonymResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/search/synonyms [post]
+func CreateSearchSynonym(c *fiber.Ctx) error {
+	input := &model.SearchSynonymInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	terms := normalizeSynonymTerms(input.Terms)
+	if len(terms) < 2 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "a synonym group needs at least two distinct terms",
+		})
+	}
+
+	now := time.Now()
+	synonym := &model.SearchSynonym{
+		ID:        uuid.New(),
+		Terms:     terms,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	searchRepo := repo.NewSearchRepository(database.GetDB())
+	if err := searchRepo.CreateSynonym(synonym); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"synonym": synonym,
+	})
+}
+
+// UpdateSearchSynonym func replaces the terms of a synonym group.
+// @Description Replace the terms of a search synonym group.
+// @Summary update a search synonym group
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param id path string true "Synonym group ID (UUID format)"
+// @Param synonym body model.SearchSynonymInput true "Synonym group"
+// @Success 200 {object} SearchSynonymResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/search/synonyms/{id} [put]
+func UpdateSearchSynonym(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid synonym ID format",
+		})
+	}
+
+	searchRepo := repo.NewSearchRepository(database.GetDB())
+
+	synonym, err := searchRepo.GetSynonym(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "synonym not found",
+		})
+	}
+
+	input := &model.SearchSynonymInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	terms := normalizeSynonymTerms(input.Terms)
+	if len(terms) < 2 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "a synonym group needs at least two distinct terms",
+		})
+	}
+
+	synonym.Terms = terms
+	if err := searchRepo.UpdateSynonym(synonym); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"synonym": synonym,
+	})
+}
+
+// DeleteSearchSynonym func deletes a synonym group.
+// @Description Delete a search synonym group.
+// @Summary delete a search synonym group
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param id path string true "Synonym group ID (UUID format)"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/search/synonyms/{id} [delete]
+func DeleteSearchSynonym(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid synonym ID format",
+		})
+	}
+
+	searchRepo := repo.NewSearchRepository(database.GetDB())
+
+	if _, err := searchRepo.GetSynonym(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "synonym not found",
+		})
+	}
+
+	if err := searchRepo.DeleteSynonym(id); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "synonym deleted successfully",
+	})
+}
+
+// RecordSearchClick func records a click on a search result.
+// @Description Record that a published product was clicked in the results of a search, for search analytics.
+// @Summary record a search result click
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param click body model.SearchClickInput true "Clicked result"
+// @Success 201 {object} SuccessResponse "success message"
+// @Failure 400,404,500 {object} ErrorResponse "Error"
+// @Router /api/v1/search/clicks [post]
+func RecordSearchClick(c *fiber.Ctx) error {
+	input := &model.SearchClickInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	// Only published products appear in search results
+	productRepo := repo.NewProductRepository(database.GetDB())
+	publishStatus, err := productRepo.GetPublishStatus(input.ProductID)
+	if err != nil || publishStatus != model.PublishStatusPublished {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	var userID *uuid.UUID
+	if id, ok := OptionalUserID(c); ok {
+		userID = &id
+	}
+
+	searchRepo := repo.NewSearchRepository(database.GetDB())
+	if err := searchRepo.LogClick(input.Query, input.ProductID, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"msg": "click recorded successfully",
+	})
+}
+
+// GetSearchReport func returns the search analytics report.
+// @Description Get the product searches of a period aggregated per query, most searched first, with their zero-result count, average result count and clicks.
+// @Summary get search analytics report
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param from query string false "Start of the period (RFC3339 format, default 30 days ago)"
+// @Param to query string false "End of the period (RFC3339 format, default now)"
+// @Param zero_results query boolean false "Only list queries that returned no results at least once"
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Success 200 {object} SearchReportResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/search/report [get]
+func GetSearchReport(c *fiber.Ctx) error {
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	to := time.Now()
+	if c.Query("to") != "" {
+		t, err := time.Parse(time.RFC3339, c.Query("to"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid to format, use RFC3339",
+			})
+		}
+		to = t
+	}
+
+	from := to.AddDate(0, 0, -30)
+	if c.Query("from") != "" {
+		t, err := time.Parse(time.RFC3339, c.Query("from"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid from format, use RFC3339",
+			})
+		}
+		from = t
+	}
+
+	if from.After(to) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "from must be before to",
+		})
+	}
+
+	zeroResultsOnly := c.QueryBool("zero_results")
+
+	searchRepo := repo.NewSearchRepository(database.GetDB())
+	stats, total, err := searchRepo.GetQueryStats(from, to, zeroResultsOnly, offset, pageSize)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"from":      from,
+		"to":        to,
+		"page":      pageNo,
+		"page_size": pageSize,
+		"total":     total,
+		"queries":   stats,
+	})
+}
@@ -0,0 +1,542 @@
+package controller
+
+import (
+	"errors"
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// StockCountResponse represents a successful stock count response.
+type StockCountResponse struct {
+	StockCount model.StockCount `json:"stock_count"`
+}
+
+// StockCountsResponse represents a paginated stock count list.
+type StockCountsResponse struct {
+	Page        int                `json:"page"`
+	Size        int                `json:"page_size"`
+	Total       int                `json:"total"`
+	StockCounts []model.StockCount `json:"stock_counts"`
+}
+
+// StockVarianceReportResponse represents the variance report of a stock count.
+type StockVarianceReportResponse struct {
+	StockCount model.StockCount           `json:"stock_count"`
+	Summary    model.StockVarianceSummary `json:"summary"`
+	Variances  []model.StockVariance      `json:"variances"`
+}
+
+var stockCountStatuses = map[string]bool{
+	model.StockCountOpen:      true,
+	model.StockCountSubmitted: true,
+	model.StockCountApproved:  true,
+	model.StockCountCancelled: true,
+}
+
+// stockCountResponse answers with the current state of a stock count
+func stockCountResponse(c *fiber.Ctx, id uuid.UUID) error {
+	stockCountRepo := repo.NewStockCountRepository(database.GetDB())
+	count, err := stockCountRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"stock_count": count,
+	})
+}
+
+// CreateStockCount func opens a stock count session.
+// @Description Open a cycle count session for a set of products in a warehouse, the default warehouse when none is given. Staff then submit the counted quantities.
+// @Summary open a stock count
+// @Tags StockCount
+// @Accept json
+// @Produce json
+// @Param stock_count body model.StockCountInput true "Products to count"
+// @Success 201 {object} StockCountResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/stock-counts [post]
+func CreateStockCount(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	input := &model.StockCountInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	var warehouse *model.Warehouse
+	if input.WarehouseID != nil {
+		warehouse, err = warehouseRepo.GetByID(*input.WarehouseID)
+	} else {
+		warehouse, err = warehouseRepo.GetDefault()
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "warehouse not found",
+		})
+	}
+	if !warehouse.IsActive {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "warehouse is not active",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	products, err := productRepo.GetByIDs(input.ProductIDs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	found := make(map[uuid.UUID]bool, len(products))
+	for _, product := range products {
+		found[product.ID] = true
+	}
+	for _, productID := range input.ProductIDs {
+		if !found[productID] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "product " + productID.String() + " not found",
+			})
+		}
+	}
+
+	now := time.Now()
+	count := &model.StockCount{
+		ID:          uuid.New(),
+		WarehouseID: warehouse.ID,
+		Status:      model.StockCountOpen,
+		Notes:       input.Notes,
+		CreatedBy:   &userID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	stockCountRepo := repo.NewStockCountRepository(database.GetDB())
+	if err := stockCountRepo.Create(count, input.ProductIDs); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	createdCount, err := stockCountRepo.GetByID(count.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"stock_count": createdCount,
+	})
+}
+
+// GetStockCount func gets a stock count session.
+// @Description Get a stock count session with its products and counted quantities.
+// @Summary get a stock count
+// @Tags StockCount
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock count ID (UUID format)"
+// @Success 200 {object} StockCountResponse
+// @Failure 400,401,403,404 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/stock-counts/{id} [get]
+func GetStockCount(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid stock count ID format",
+		})
+	}
+
+	stockCountRepo := repo.NewStockCountRepository(database.GetDB())
+	count, err := stockCountRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "stock count not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"stock_count": count,
+	})
+}
+
+// ListStockCounts func lists stock count sessions.
+// @Description List stock count sessions, most recent first.
+// @Summary list stock counts
+// @Tags StockCount
+// @Accept json
+// @Produce json
+// @Param warehouse_id query string false "Warehouse ID (UUID format)"
+// @Param status query string false "Status (open, submitted, approved, cancelled)"
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Success 200 {object} StockCountsResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/stock-counts [get]
+func ListStockCounts(c *fiber.Ctx) error {
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	var warehouseID *uuid.UUID
+	if warehouseIDStr := c.Query("warehouse_id"); warehouseIDStr != "" {
+		id, err := uuid.Parse(warehouseIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": "invalid warehouse ID format",
+			})
+		}
+		warehouseID = &id
+	}
+
+	status := c.Query("status")
+	if status != "" && !stockCountStatuses[status] {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid stock count status",
+		})
+	}
+
+	stockCountRepo := repo.NewStockCountRepository(database.GetDB())
+	counts, total, err := stockCountRepo.List(offset, pageSize, warehouseID, status)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"page":         pageNo,
+		"page_size":    pageSize,
+		"total":        total,
+		"stock_counts": counts,
+	})
+}
+
+// RecordStockCounts func submits counted quantities.
+// @Description Submit the counted quantities of products of an open stock count. The system stock at the time of counting is recorded next to each count; counting a product again replaces its count.
+// @Summary record counted quantities
+// @Tags StockCount
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock count ID (UUID format)"
+// @Param counts body model.StockCountEntriesInput true "Counted quantities"
+// @Success 200 {object} StockCountResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/stock-counts/{id}/lines [put]
+func RecordStockCounts(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid stock count ID format",
+		})
+	}
+
+	input := &model.StockCountEntriesInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	stockCountRepo := repo.NewStockCountRepository(database.GetDB())
+	if _, err := stockCountRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "stock count not found",
+		})
+	}
+
+	if err := stockCountRepo.RecordCounts(id, input.Lines, &userID, time.Now()); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrStockCountNotOpen):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		case errors.Is(err, repo.ErrProductNotInCount):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return stockCountResponse(c, id)
+}
+
+// SubmitStockCount func closes counting for approval.
+// @Description Submit an open stock count for approval, once every product is counted.
+// @Summary submit a stock count
+// @Tags StockCount
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock count ID (UUID format)"
+// @Success 200 {object} StockCountResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/stock-counts/{id}/submit [post]
+func SubmitStockCount(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid stock count ID format",
+		})
+	}
+
+	stockCountRepo := repo.NewStockCountRepository(database.GetDB())
+	count, err := stockCountRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "stock count not found",
+		})
+	}
+
+	if count.Status != model.StockCountOpen {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "stock count is not open",
+		})
+	}
+
+	if count.CountedCount < count.LineCount {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "every product must be counted before submitting",
+		})
+	}
+
+	if err := stockCountRepo.Submit(id, time.Now()); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return stockCountResponse(c, id)
+}
+
+// GetStockCountVariance func returns the variance report of a stock count.
+// @Description Get the difference between the counted quantity and the system stock of each counted product, valued at cost price, largest differences first.
+// @Summary get stock count variance report
+// @Tags StockCount
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock count ID (UUID format)"
+// @Success 200 {object} StockVarianceReportResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/stock-counts/{id}/variance [get]
+func GetStockCountVariance(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid stock count ID format",
+		})
+	}
+
+	stockCountRepo := repo.NewStockCountRepository(database.GetDB())
+	count, err := stockCountRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "stock count not found",
+		})
+	}
+
+	variances, summary, err := stockCountRepo.GetVariances(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	count.Lines = nil
+	return c.JSON(fiber.Map{
+		"stock_count": count,
+		"summary":     summary,
+		"variances":   variances,
+	})
+}
+
+// ApproveStockCount func posts the variances of a stock count.
+// @Description Approve a submitted stock count. Each variance is posted as an adjustment inventory movement referencing the stock count, and the warehouse and product stock are corrected.
+// @Summary approve a stock count
+// @Tags StockCount
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock count ID (UUID format)"
+// @Success 200 {object} StockCountResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/stock-counts/{id}/approve [post]
+func ApproveStockCount(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid stock count ID format",
+		})
+	}
+
+	stockCountRepo := repo.NewStockCountRepository(database.GetDB())
+	if _, err := stockCountRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "stock count not found",
+		})
+	}
+
+	if err := stockCountRepo.Approve(id, &userID, time.Now()); err != nil {
+		if errors.Is(err, repo.ErrStockCountNotSubmitted) || errors.Is(err, repo.ErrInsufficientStock) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return stockCountResponse(c, id)
+}
+
+// CancelStockCount func cancels a stock count.
+// @Description Cancel a stock count that is not approved yet. Stock is left unchanged.
+// @Summary cancel a stock count
+// @Tags StockCount
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock count ID (UUID format)"
+// @Success 200 {object} StockCountResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/stock-counts/{id}/cancel [post]
+func CancelStockCount(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid stock count ID format",
+		})
+	}
+
+	stockCountRepo := repo.NewStockCountRepository(database.GetDB())
+	count, err := stockCountRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "stock count not found",
+		})
+	}
+
+	if count.Status != model.StockCountOpen && count.Status != model.StockCountSubmitted {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"msg": "only open or submitted stock counts can be cancelled",
+		})
+	}
+
+	if err := stockCountRepo.Cancel(id); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return stockCountResponse(c, id)
+}
@@ -0,0 +1,472 @@
+package controller
+
+import (
+	"errors"
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// SupplierResponse represents a successful supplier response.
+type SupplierResponse struct {
+	Supplier model.Supplier `json:"supplier"`
+}
+
+// SuppliersResponse represents a paginated supplier list.
+type SuppliersResponse struct {
+	Page      int              `json:"page"`
+	Size      int              `json:"page_size"`
+	Total     int              `json:"total"`
+	Suppliers []model.Supplier `json:"suppliers"`
+}
+
+// ProductSuppliersResponse represents a list of product supplier links.
+type ProductSuppliersResponse struct {
+	Suppliers []model.ProductSupplier `json:"suppliers"`
+}
+
+// SupplierProductsResponse represents the product links of a supplier.
+type SupplierProductsResponse struct {
+	Products []model.ProductSupplier `json:"products"`
+}
+
+// ProductSupplierResponse represents a product supplier link.
+type ProductSupplierResponse struct {
+	Link model.ProductSupplier `json:"link"`
+}
+
+// CreateSupplier func for creating a new supplier.
+// @Description Create a new supplier.
+// @Summary create a new supplier
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param supplier body model.SupplierInput true "Create new supplier"
+// @Success 201 {object} SupplierResponse
+// @Failure 400,401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/suppliers [post]
+func CreateSupplier(c *fiber.Ctx) error {
+	input := &model.SupplierInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	now := time.Now()
+	supplier := &model.Supplier{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(input.Name),
+		Email:     input.Email,
+		Phone:     input.Phone,
+		Address:   input.Address,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if input.IsActive != nil {
+		supplier.IsActive = *input.IsActive
+	}
+
+	supplierRepo := repo.NewSupplierRepository(database.GetDB())
+	if err := supplierRepo.Create(supplier); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"supplier": supplier,
+	})
+}
+
+// GetSupplier func gets a supplier by ID.
+// @Description Get a supplier by ID.
+// @Summary get a supplier
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID (UUID format)"
+// @Success 200 {object} SupplierResponse
+// @Failure 400,401,403,404 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/suppliers/{id} [get]
+func GetSupplier(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid supplier ID format",
+		})
+	}
+
+	supplierRepo := repo.NewSupplierRepository(database.GetDB())
+	supplier, err := supplierRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "supplier not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"supplier": supplier,
+	})
+}
+
+// ListSuppliers func lists suppliers.
+// @Description List suppliers ordered by name.
+// @Summary list suppliers
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param search query string false "Search term for name or email"
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Success 200 {object} SuppliersResponse
+// @Failure 401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/suppliers [get]
+func ListSuppliers(c *fiber.Ctx) error {
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	supplierRepo := repo.NewSupplierRepository(database.GetDB())
+	suppliers, total, err := supplierRepo.List(offset, pageSize, strings.TrimSpace(c.Query("search")))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"page":      pageNo,
+		"page_size": pageSize,
+		"total":     total,
+		"suppliers": suppliers,
+	})
+}
+
+// UpdateSupplier func updates a supplier.
+// @Description Update a supplier.
+// @Summary update a supplier
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID (UUID format)"
+// @Param supplier body model.SupplierInput true "Update supplier"
+// @Success 200 {object} SupplierResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/suppliers/{id} [put]
+func UpdateSupplier(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid supplier ID format",
+		})
+	}
+
+	input := &model.SupplierInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	supplierRepo := repo.NewSupplierRepository(database.GetDB())
+	supplier, err := supplierRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "supplier not found",
+		})
+	}
+
+	supplier.Name = strings.TrimSpace(input.Name)
+	supplier.Email = input.Email
+	supplier.Phone = input.Phone
+	supplier.Address = input.Address
+	if input.IsActive != nil {
+		supplier.IsActive = *input.IsActive
+	}
+
+	if err := supplierRepo.Update(supplier); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"supplier": supplier,
+	})
+}
+
+// DeleteSupplier func deletes a supplier.
+// @Description Delete a supplier and its product links. Suppliers with purchase orders that are not fully received cannot be deleted.
+// @Summary delete a supplier
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID (UUID format)"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/suppliers/{id} [delete]
+func DeleteSupplier(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid supplier ID format",
+		})
+	}
+
+	supplierRepo := repo.NewSupplierRepository(database.GetDB())
+	if _, err := supplierRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "supplier not found",
+		})
+	}
+
+	if err := supplierRepo.Delete(id); err != nil {
+		if errors.Is(err, repo.ErrSupplierHasPurchaseOrders) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"msg": "supplier cannot be deleted because it has open purchase orders",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "supplier deleted successfully",
+	})
+}
+
+// GetSupplierProducts func lists the products of a supplier.
+// @Description Get the products a supplier delivers, with the supplier SKU, lead time and unit cost.
+// @Summary get supplier products
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID (UUID format)"
+// @Success 200 {object} SupplierProductsResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/suppliers/{id}/products [get]
+func GetSupplierProducts(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid supplier ID format",
+		})
+	}
+
+	supplierRepo := repo.NewSupplierRepository(database.GetDB())
+	if _, err := supplierRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "supplier not found",
+		})
+	}
+
+	links, err := supplierRepo.GetProducts(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"products": links,
+	})
+}
+
+// SaveProductSupplier func links a product to a supplier.
+// @Description Link a product to a supplier, or update the link: supplier SKU, lead time in days, unit cost and whether it is the preferred supplier of the product.
+// @Summary link a product to a supplier
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID (UUID format)"
+// @Param product_id path string true "Product ID (UUID format)"
+// @Param link body model.ProductSupplierInput true "Product supplier link"
+// @Success 200 {object} ProductSupplierResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/suppliers/{id}/products/{product_id} [put]
+func SaveProductSupplier(c *fiber.Ctx) error {
+	supplierID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid supplier ID format",
+		})
+	}
+
+	productID, err := uuid.Parse(c.Params("product_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	input := &model.ProductSupplierInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	supplierRepo := repo.NewSupplierRepository(database.GetDB())
+	if _, err := supplierRepo.GetByID(supplierID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "supplier not found",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	if _, err := productRepo.GetByID(productID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	link := &model.ProductSupplier{
+		ProductID:    productID,
+		SupplierID:   supplierID,
+		SupplierSKU:  strings.TrimSpace(input.SupplierSKU),
+		LeadTimeDays: input.LeadTimeDays,
+		UnitCost:     input.UnitCost,
+		IsPreferred:  input.IsPreferred,
+	}
+	if err := supplierRepo.SaveLink(link); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	savedLink, err := supplierRepo.GetLink(productID, supplierID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"link": savedLink,
+	})
+}
+
+// DeleteProductSupplier func unlinks a product from a supplier.
+// @Description Remove the link between a product and a supplier.
+// @Summary unlink a product from a supplier
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID (UUID format)"
+// @Param product_id path string true "Product ID (UUID format)"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/suppliers/{id}/products/{product_id} [delete]
+func DeleteProductSupplier(c *fiber.Ctx) error {
+	supplierID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid supplier ID format",
+		})
+	}
+
+	productID, err := uuid.Parse(c.Params("product_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	supplierRepo := repo.NewSupplierRepository(database.GetDB())
+	if _, err := supplierRepo.GetLink(productID, supplierID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product is not linked to this supplier",
+		})
+	}
+
+	if err := supplierRepo.DeleteLink(productID, supplierID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "product unlinked from supplier successfully",
+	})
+}
+
+// GetProductSuppliers func lists the suppliers of a product.
+// @Description Get the suppliers of a product, preferred supplier first.
+// @Summary get product suppliers
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID format)"
+// @Success 200 {object} ProductSuppliersResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/products/{id}/suppliers [get]
+func GetProductSuppliers(c *fiber.Ctx) error {
+	productID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	if _, err := productRepo.GetByID(productID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	supplierRepo := repo.NewSupplierRepository(database.GetDB())
+	links, err := supplierRepo.GetByProductID(productID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"suppliers": links,
+	})
+}
@@ -0,0 +1,609 @@
+package controller
+
+import (
+	"errors"
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/validator"
+	"golang-test1/platform/database"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// WarehouseResponse represents a successful warehouse response.
+type WarehouseResponse struct {
+	Warehouse model.Warehouse `json:"warehouse"`
+}
+
+// WarehousesResponse represents a warehouse list.
+type WarehousesResponse struct {
+	Warehouses []model.Warehouse `json:"warehouses"`
+}
+
+// WarehouseStockResponse represents a paginated list of warehouse stock levels.
+type WarehouseStockResponse struct {
+	Page  int                    `json:"page"`
+	Size  int                    `json:"page_size"`
+	Total int                    `json:"total"`
+	Stock []model.WarehouseStock `json:"stock"`
+}
+
+// ProductStockResponse represents the stock of a product per warehouse.
+type ProductStockResponse struct {
+	StockQuantity int                    `json:"stock_quantity"`
+	Warehouses    []model.WarehouseStock `json:"warehouses"`
+}
+
+// StockTransferResponse represents a completed stock transfer.
+type StockTransferResponse struct {
+	Transfer model.StockTransfer `json:"transfer"`
+}
+
+// checkWarehouseInput checks the warehouse input against the existing warehouse, nil
+// for a new one. It returns the status and message for the client when the input
+// is rejected.
+func checkWarehouseInput(input *model.WarehouseInput, id uuid.UUID, existing *model.Warehouse) (int, string, error) {
+	isActive := existing == nil || existing.IsActive
+	if input.IsActive != nil {
+		isActive = *input.IsActive
+	}
+	if input.IsDefault && !isActive {
+		return fiber.StatusBadRequest, "the default warehouse must be active", nil
+	}
+	if existing != nil && existing.IsDefault && !input.IsDefault {
+		return fiber.StatusBadRequest, "make another warehouse the default instead", nil
+	}
+
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	exists, err := warehouseRepo.CodeExists(input.Code, id)
+	if err != nil {
+		return 0, "", err
+	}
+	if exists {
+		return fiber.StatusConflict, "warehouse code already exists", nil
+	}
+
+	return 0, "", nil
+}
+
+// CreateWarehouse func for creating a new warehouse.
+// @Description Create a new warehouse. Making it the default warehouse takes the flag away from the previous default.
+// @Summary create a new warehouse
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Param warehouse body model.WarehouseInput true "Create new warehouse"
+// @Success 201 {object} WarehouseResponse
+// @Failure 400,401,403,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/warehouses [post]
+func CreateWarehouse(c *fiber.Ctx) error {
+	input := &model.WarehouseInput{}
+
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	input.Code = strings.ToUpper(strings.TrimSpace(input.Code))
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	id := uuid.New()
+	status, msg, err := checkWarehouseInput(input, id, nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if msg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	now := time.Now()
+	warehouse := &model.Warehouse{
+		ID:        id,
+		Code:      input.Code,
+		Name:      strings.TrimSpace(input.Name),
+		Address:   input.Address,
+		IsActive:  input.IsActive == nil || *input.IsActive,
+		IsDefault: input.IsDefault,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	if err := warehouseRepo.Create(warehouse); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"warehouse": warehouse,
+	})
+}
+
+// GetWarehouse func gets a warehouse by ID.
+// @Description Get a warehouse by ID.
+// @Summary get a warehouse
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Param id path string true "Warehouse ID (UUID format)"
+// @Success 200 {object} WarehouseResponse
+// @Failure 400,401,403,404 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/warehouses/{id} [get]
+func GetWarehouse(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid warehouse ID format",
+		})
+	}
+
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	warehouse, err := warehouseRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "warehouse not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"warehouse": warehouse,
+	})
+}
+
+// ListWarehouses func lists the warehouses.
+// @Description List the warehouses, the default warehouse first.
+// @Summary list warehouses
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Success 200 {object} WarehousesResponse
+// @Failure 401,403,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/warehouses [get]
+func ListWarehouses(c *fiber.Ctx) error {
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	warehouses, err := warehouseRepo.List()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"warehouses": warehouses,
+	})
+}
+
+// UpdateWarehouse func updates a warehouse.
+// @Description Update a warehouse. The default warehouse stays the default until another warehouse is made the default.
+// @Summary update a warehouse
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Param id path string true "Warehouse ID (UUID format)"
+// @Param warehouse body model.WarehouseInput true "Update warehouse"
+// @Success 200 {object} WarehouseResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/warehouses/{id} [put]
+func UpdateWarehouse(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid warehouse ID format",
+		})
+	}
+
+	input := &model.WarehouseInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	input.Code = strings.ToUpper(strings.TrimSpace(input.Code))
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	warehouse, err := warehouseRepo.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "warehouse not found",
+		})
+	}
+
+	status, msg, err := checkWarehouseInput(input, id, warehouse)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+	if msg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"msg": msg,
+		})
+	}
+
+	warehouse.Code = input.Code
+	warehouse.Name = strings.TrimSpace(input.Name)
+	warehouse.Address = input.Address
+	if input.IsActive != nil {
+		warehouse.IsActive = *input.IsActive
+	}
+	warehouse.IsDefault = input.IsDefault
+
+	if err := warehouseRepo.Update(warehouse); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"warehouse": warehouse,
+	})
+}
+
+// DeleteWarehouse func deletes a warehouse.
+// @Description Delete an empty warehouse. The default warehouse cannot be deleted; transfer the stock of a warehouse away before deleting it.
+// @Summary delete a warehouse
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Param id path string true "Warehouse ID (UUID format)"
+// @Success 200 {object} SuccessResponse "success message"
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/warehouses/{id} [delete]
+func DeleteWarehouse(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid warehouse ID format",
+		})
+	}
+
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	if _, err := warehouseRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "warehouse not found",
+		})
+	}
+
+	if err := warehouseRepo.Delete(id); err != nil {
+		if errors.Is(err, repo.ErrWarehouseIsDefault) || errors.Is(err, repo.ErrWarehouseHasStock) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"msg": "warehouse deleted successfully",
+	})
+}
+
+// GetWarehouseStock func lists the stock held in a warehouse.
+// @Description Get the products in stock in a warehouse, by SKU.
+// @Summary get warehouse stock
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Param id path string true "Warehouse ID (UUID format)"
+// @Param search query string false "Search term for SKU or name"
+// @Param page query integer false "Page number"
+// @Param page_size query integer false "Page size"
+// @Success 200 {object} WarehouseStockResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/warehouses/{id}/stock [get]
+func GetWarehouseStock(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid warehouse ID format",
+		})
+	}
+
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	if _, err := warehouseRepo.GetByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "warehouse not found",
+		})
+	}
+
+	pageNo, pageSize := GetPagination(c)
+	offset := (pageNo - 1) * pageSize
+
+	stock, total, err := warehouseRepo.GetStock(id, offset, pageSize, strings.TrimSpace(c.Query("search")))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"page":      pageNo,
+		"page_size": pageSize,
+		"total":     total,
+		"stock":     stock,
+	})
+}
+
+// SetWarehouseStock func sets the stock of a product in a warehouse.
+// @Description Set the stock level of a product in a warehouse, after a count for example. The difference is recorded as an adjustment movement and the product stock becomes the sum over all warehouses.
+// @Summary set warehouse stock
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Param id path string true "Warehouse ID (UUID format)"
+// @Param product_id path string true "Product ID (UUID format)"
+// @Param stock body model.WarehouseStockInput true "Stock level"
+// @Success 200 {object} ProductStockResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/warehouses/{id}/stock/{product_id} [put]
+func SetWarehouseStock(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	warehouseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid warehouse ID format",
+		})
+	}
+
+	productID, err := uuid.Parse(c.Params("product_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	input := &model.WarehouseStockInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	if _, err := warehouseRepo.GetByID(warehouseID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "warehouse not found",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	if _, err := productRepo.GetByID(productID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	if err := warehouseRepo.SetStock(warehouseID, productID, input.Quantity, input.Notes, &userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return productStockResponse(c, productID)
+}
+
+// productStockResponse answers with the stock of a product per warehouse
+func productStockResponse(c *fiber.Ctx, productID uuid.UUID) error {
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	stock, err := warehouseRepo.GetProductStock(productID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	total := 0
+	for _, level := range stock {
+		total += level.Quantity
+	}
+
+	return c.JSON(fiber.Map{
+		"stock_quantity": total,
+		"warehouses":     stock,
+	})
+}
+
+// GetProductStock func gets the stock of a product per warehouse.
+// @Description Get the stock levels of a product in each warehouse. Their sum is the stock quantity of the product.
+// @Summary get product stock per warehouse
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID format)"
+// @Success 200 {object} ProductStockResponse
+// @Failure 400,401,403,404,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/products/{id}/stock [get]
+func GetProductStock(c *fiber.Ctx) error {
+	productID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "invalid product ID format",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	if _, err := productRepo.GetByID(productID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	return productStockResponse(c, productID)
+}
+
+// TransferStock func moves stock between warehouses.
+// @Description Move stock of a product from one warehouse to another. The transfer is recorded as a transfer_out and a transfer_in inventory movement referencing the transfer ID; the product stock quantity does not change.
+// @Summary transfer stock between warehouses
+// @Tags Warehouse
+// @Accept json
+// @Produce json
+// @Param transfer body model.StockTransferInput true "Stock transfer"
+// @Success 201 {object} StockTransferResponse
+// @Failure 400,401,403,404,409,500 {object} ErrorResponse "Error"
+// @Security ApiKeyAuth
+// @Router /api/v1/warehouses/transfers [post]
+func TransferStock(c *fiber.Ctx) error {
+	// Get user ID from JWT context
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userIDValue := claims["user_id"]
+	if userIDValue == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, user ID not found",
+		})
+	}
+
+	userIDStr, ok := userIDValue.(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID format",
+		})
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"msg": "unauthorized, invalid user ID",
+		})
+	}
+
+	input := &model.StockTransferInput{}
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	validate := validator.NewValidator()
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg":    "invalid input found",
+			"errors": validator.ValidatorErrors(err),
+		})
+	}
+
+	if input.FromWarehouseID == input.ToWarehouseID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "source and destination warehouses must differ",
+		})
+	}
+
+	warehouseRepo := repo.NewWarehouseRepository(database.GetDB())
+	if _, err := warehouseRepo.GetByID(input.FromWarehouseID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "source warehouse not found",
+		})
+	}
+
+	destination, err := warehouseRepo.GetByID(input.ToWarehouseID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "destination warehouse not found",
+		})
+	}
+	if !destination.IsActive {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"msg": "destination warehouse is not active",
+		})
+	}
+
+	productRepo := repo.NewProductRepository(database.GetDB())
+	if _, err := productRepo.GetByID(input.ProductID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"msg": "product not found",
+		})
+	}
+
+	transfer := &model.StockTransfer{
+		ID:              uuid.New(),
+		FromWarehouseID: input.FromWarehouseID,
+		ToWarehouseID:   input.ToWarehouseID,
+		ProductID:       input.ProductID,
+		Quantity:        input.Quantity,
+		CreatedAt:       time.Now(),
+	}
+
+	if err := warehouseRepo.Transfer(transfer, input.Notes, &userID); err != nil {
+		if errors.Is(err, repo.ErrInsufficientStock) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"msg": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"msg": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"transfer": transfer,
+	})
+}
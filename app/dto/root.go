@@ -2,6 +2,9 @@ package dto
 
 import (
 	"golang-test1/app/model"
+	"math"
+	"reflect"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -45,30 +48,32 @@ func ToUsers(users []*model.User) []*User {
 
 // Category DTO
 type Category struct {
-	ID           uuid.UUID  `json:"id"`
-	Name         string     `json:"name"`
-	Slug         string     `json:"slug"`
-	Description  string     `json:"description,omitempty"`
-	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
-	IsActive     bool       `json:"is_active"`
-	DisplayOrder int        `json:"display_order"`
-	ProductCount int        `json:"product_count,omitempty"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	ID                  uuid.UUID  `json:"id"`
+	Name                string     `json:"name"`
+	Slug                string     `json:"slug"`
+	Description         string     `json:"description,omitempty"`
+	ParentID            *uuid.UUID `json:"parent_id,omitempty"`
+	IsActive            bool       `json:"is_active"`
+	DisplayOrder        int        `json:"display_order"`
+	ProductCount        int        `json:"product_count,omitempty"`
+	SubtreeProductCount int        `json:"subtree_product_count,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
 }
 
 func ToCategory(c *model.Category) *Category {
 	return &Category{
-		ID:           c.ID,
-		Name:         c.Name,
-		Slug:         c.Slug,
-		Description:  c.Description,
-		ParentID:     c.ParentID,
-		IsActive:     c.IsActive,
-		DisplayOrder: c.DisplayOrder,
-		ProductCount: c.ProductCount,
-		CreatedAt:    c.CreatedAt,
-		UpdatedAt:    c.UpdatedAt,
+		ID:                  c.ID,
+		Name:                c.Name,
+		Slug:                c.Slug,
+		Description:         c.Description,
+		ParentID:            c.ParentID,
+		IsActive:            c.IsActive,
+		DisplayOrder:        c.DisplayOrder,
+		ProductCount:        c.ProductCount,
+		SubtreeProductCount: c.SubtreeProductCount,
+		CreatedAt:           c.CreatedAt,
+		UpdatedAt:           c.UpdatedAt,
 	}
 }
 
@@ -80,21 +85,56 @@ func ToCategories(categories []model.Category) []*Category {
 	return res
 }
 
+// CategoryNode DTO is a category of the category tree with its children
+type CategoryNode struct {
+	Category
+	Depth    int             `json:"depth"`
+	Children []*CategoryNode `json:"children"`
+}
+
+// ToCategoryTree nests the tree rows under their parents. Rows must list parents
+// before their children, as the category repository returns them.
+func ToCategoryTree(rows []model.CategoryTreeRow) []*CategoryNode {
+	roots := []*CategoryNode{}
+	nodes := make(map[uuid.UUID]*CategoryNode, len(rows))
+	for i := range rows {
+		node := &CategoryNode{
+			Category: *ToCategory(&rows[i].Category),
+			Depth:    rows[i].Depth,
+			Children: []*CategoryNode{},
+		}
+		nodes[node.ID] = node
+
+		if node.Depth > 0 && node.ParentID != nil {
+			if parent, ok := nodes[*node.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
 // Product DTO
 type Product struct {
-	ID            uuid.UUID      `json:"id"`
-	SKU           string         `json:"sku"`
-	Name          string         `json:"name"`
-	Description   string         `json:"description"`
-	Price         float64        `json:"price"`
-	SalePrice     float64        `json:"sale_price,omitempty"`
-	CostPrice     float64        `json:"cost_price,omitempty"`
-	StockQuantity int            `json:"stock_quantity"`
-	Status        string         `json:"status"`
-	Attributes    map[string]any `json:"attributes,omitempty"`
-	Categories    []*Category    `json:"categories,omitempty"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
+	ID            uuid.UUID            `json:"id"`
+	SKU           string               `json:"sku"`
+	Name          string               `json:"name"`
+	Description   string               `json:"description"`
+	Price         float64              `json:"price"`
+	SalePrice     float64              `json:"sale_price,omitempty"`
+	CostPrice     float64              `json:"cost_price,omitempty"`
+	StockQuantity int                  `json:"stock_quantity"`
+	Status        string               `json:"status"`
+	PublishStatus string               `json:"publish_status"`
+	PublishAt     *time.Time           `json:"publish_at,omitempty"`
+	PublishedAt   *time.Time           `json:"published_at,omitempty"`
+	Attributes    map[string]any       `json:"attributes,omitempty"`
+	Categories    []*Category          `json:"categories,omitempty"`
+	Rating        *model.RatingSummary `json:"rating,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
 }
 
 func ToProduct(p *model.Product) *Product {
@@ -108,8 +148,12 @@ func ToProduct(p *model.Product) *Product {
 		CostPrice:     p.CostPrice,
 		StockQuantity: p.StockQuantity,
 		Status:        p.Status,
+		PublishStatus: p.PublishStatus,
+		PublishAt:     p.PublishAt,
+		PublishedAt:   p.PublishedAt,
 		Attributes:    p.Attributes,
 		Categories:    ToCategories(p.Categories),
+		Rating:        p.Rating,
 		CreatedAt:     p.CreatedAt,
 		UpdatedAt:     p.UpdatedAt,
 	}
@@ -124,6 +168,124 @@ func ToProducts(products []model.Product) []*Product {
 	return res
 }
 
+// ProductBatchResult DTO for one entry of a batch product lookup, in request order
+type ProductBatchResult struct {
+	ID      *uuid.UUID `json:"id,omitempty"`
+	SKU     string     `json:"sku,omitempty"`
+	Found   bool       `json:"found"`
+	Product *Product   `json:"product,omitempty"`
+}
+
+// ComparisonRow DTO for one row of a product comparison. Values are aligned with
+// the compared products and are null where a product has no value.
+type ComparisonRow struct {
+	Key     string `json:"key"`
+	Values  []any  `json:"values"`
+	Differs bool   `json:"differs"`
+}
+
+// ProductComparison DTO for side by side product comparison
+type ProductComparison struct {
+	Products         []*Product      `json:"products"`
+	Attributes       []ComparisonRow `json:"attributes"`
+	Summary          []ComparisonRow `json:"summary"`
+	SharedCategories []*Category     `json:"shared_categories"`
+}
+
+func ToProductComparison(products []model.Product, ratings map[uuid.UUID]model.RatingStats) *ProductComparison {
+	comparison := &ProductComparison{
+		Products:         ToProducts(products),
+		Attributes:       []ComparisonRow{},
+		SharedCategories: []*Category{},
+	}
+
+	// Attribute matrix over the union of attribute keys
+	keys := map[string]bool{}
+	for _, product := range products {
+		for key := range product.Attributes {
+			keys[key] = true
+		}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		values := make([]any, len(products))
+		for i, product := range products {
+			values[i] = product.Attributes[key]
+		}
+		comparison.Attributes = append(comparison.Attributes, newComparisonRow(key, values))
+	}
+
+	// Price and rating summary rows
+	prices := make([]any, len(products))
+	salePrices := make([]any, len(products))
+	effectivePrices := make([]any, len(products))
+	averageRatings := make([]any, len(products))
+	reviewCounts := make([]any, len(products))
+	for i := range products {
+		prices[i] = products[i].Price
+		if products[i].SalePrice > 0 {
+			salePrices[i] = products[i].SalePrice
+		}
+		effectivePrices[i] = products[i].EffectivePrice()
+		rating := ratings[products[i].ID]
+		if rating.ReviewCount > 0 {
+			averageRatings[i] = math.Round(rating.AverageRating*100) / 100
+		}
+		reviewCounts[i] = rating.ReviewCount
+	}
+	comparison.Summary = []ComparisonRow{
+		newComparisonRow("price", prices),
+		newComparisonRow("sale_price", salePrices),
+		newComparisonRow("effective_price", effectivePrices),
+		newComparisonRow("average_rating", averageRatings),
+		newComparisonRow("review_count", reviewCounts),
+	}
+
+	// Categories every compared product belongs to
+	if len(products) > 0 {
+		for _, category := range products[0].Categories {
+			shared := true
+			for _, product := range products[1:] {
+				if !hasCategory(product.Categories, category.ID) {
+					shared = false
+					break
+				}
+			}
+			if shared {
+				category := category
+				comparison.SharedCategories = append(comparison.SharedCategories, ToCategory(&category))
+			}
+		}
+	}
+
+	return comparison
+}
+
+func newComparisonRow(key string, values []any) ComparisonRow {
+	row := ComparisonRow{Key: key, Values: values}
+	for i := 1; i < len(values); i++ {
+		if !reflect.DeepEqual(values[i], values[0]) {
+			row.Differs = true
+			break
+		}
+	}
+	return row
+}
+
+func hasCategory(categories []model.Category, id uuid.UUID) bool {
+	for _, category := range categories {
+		if category.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 // WishlistItem DTO for adding a product to wishlist
 type WishlistItemRequest struct {
 	ProductID string `json:"product_id" example:"5c9f8f9e-7c1f-4b9c-8c1f-7c1f4b9c8c1f"`
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductBarcode is a barcode printed on a product
+type ProductBarcode struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	Code      string    `json:"code" db:"code"`
+	Type      string    `json:"type" db:"type"`
+	GTIN      string    `json:"gtin" db:"gtin"`
+	IsPrimary bool      `json:"is_primary" db:"is_primary"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Product barcode input. Without a type, the type follows from the length of the code;
+// the check digit is validated either way.
+type ProductBarcodeInput struct {
+	Code      string `json:"code" validate:"required,barcode" example:"4006381333931"`
+	Type      string `json:"type" validate:"omitempty,oneof=ean8 upca ean13 gtin14" example:"ean13"`
+	IsPrimary bool   `json:"is_primary"`
+}
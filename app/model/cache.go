@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+)
+
+// CatalogueVersion describes the freshness of a set of catalogue rows. It is
+// cheap to compute and is used to build HTTP cache validators (ETag and
+// Last-Modified) without loading the rows themselves.
+type CatalogueVersion struct {
+	LastModified time.Time `db:"last_modified"`
+	Count        int       `db:"count"`
+}
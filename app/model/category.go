@@ -6,17 +6,24 @@ import (
 )
 
 type Category struct {
-	ID           uuid.UUID  `json:"id" db:"id"`
-	Name         string     `json:"name" db:"name"`
-	Slug         string     `json:"slug" db:"slug"`
-	Description  string     `json:"description,omitempty" db:"description"`
-	ParentID     *uuid.UUID `json:"parent_id,omitempty" db:"parent_id"`
-	IsActive     bool       `json:"is_active" db:"is_active"`
-	DisplayOrder int        `json:"display_order" db:"display_order"`
-	ProductCount int        `json:"product_count,omitempty"`
-	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
-	IsDeleted    bool       `json:"is_deleted,omitempty" db:"is_deleted"`
+	ID                  uuid.UUID  `json:"id" db:"id"`
+	Name                string     `json:"name" db:"name"`
+	Slug                string     `json:"slug" db:"slug"`
+	Description         string     `json:"description,omitempty" db:"description"`
+	ParentID            *uuid.UUID `json:"parent_id,omitempty" db:"parent_id"`
+	IsActive            bool       `json:"is_active" db:"is_active"`
+	DisplayOrder        int        `json:"display_order" db:"display_order"`
+	ProductCount        int        `json:"product_count,omitempty" db:"product_count"`
+	SubtreeProductCount int        `json:"subtree_product_count,omitempty" db:"subtree_product_count"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+	IsDeleted           bool       `json:"is_deleted,omitempty" db:"is_deleted"`
+}
+
+// CategoryTreeRow is a category of the category tree with its depth below the top category
+type CategoryTreeRow struct {
+	Category
+	Depth int `db:"depth"`
 }
 
 // Category creation/update input
@@ -27,3 +34,54 @@ type CategoryInput struct {
 	IsActive     bool       `json:"is_active"`
 	DisplayOrder int        `json:"display_order"`
 }
+
+// MaxCategoryDepth is the number of levels the category tree may have
+const MaxCategoryDepth = 5
+
+// Category move input, without a parent the category becomes a top category.
+// Position is the place among the new siblings counted from 0, the category goes last without it.
+type CategoryMoveInput struct {
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	Position *int       `json:"position,omitempty" validate:"omitempty,min=0" example:"0"`
+}
+
+// Category reorder input, listing every child of the parent (or every top category) in the new order
+type CategoryReorderInput struct {
+	ParentID    *uuid.UUID  `json:"parent_id,omitempty"`
+	CategoryIDs []uuid.UUID `json:"category_ids" validate:"required,min=1"`
+}
+
+// Category merge input. With preview the merge is only worked out, nothing changes.
+type CategoryMergeInput struct {
+	TargetID uuid.UUID `json:"target_id" validate:"required"`
+	Preview  bool      `json:"preview" example:"true"`
+}
+
+// CategoryMerge is the outcome of merging a source category into a target. ProductsMoved
+// counts the products newly assigned to the target, ProductsAlreadyInTarget those of the
+// source that already were.
+type CategoryMerge struct {
+	SourceID                uuid.UUID `json:"source_id"`
+	TargetID                uuid.UUID `json:"target_id"`
+	ProductsMoved           int       `json:"products_moved"`
+	ProductsAlreadyInTarget int       `json:"products_already_in_target"`
+	ChildrenMoved           int       `json:"children_moved"`
+	SavedSearchesUpdated    int       `json:"saved_searches_updated"`
+	CollectionsUpdated      int       `json:"collections_updated"`
+	RedirectedSlugs         []string  `json:"redirected_slugs"`
+	Preview                 bool      `json:"preview"`
+}
+
+// PinnedProduct is a product pinned at a position within a category
+type PinnedProduct struct {
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	SKU       string    `json:"sku" db:"sku"`
+	Name      string    `json:"name" db:"name"`
+	Position  int       `json:"position" db:"pin_position"`
+	PinnedAt  time.Time `json:"pinned_at" db:"pinned_at"`
+}
+
+// Category merchandising input, the products to pin in order. Products left out are unpinned.
+type CategoryMerchandisingInput struct {
+	ProductIDs []uuid.UUID `json:"product_ids" validate:"max=100"`
+}
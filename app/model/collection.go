@@ -0,0 +1,83 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Collection override modes
+const (
+	CollectionOverrideInclude = "include"
+	CollectionOverrideExclude = "exclude"
+)
+
+// CollectionRules select the products of a collection. They use the GetProducts filter
+// names, plus attribute values (exact text match) and tags (any of the values of the
+// "tags" attribute). A product matches when it passes every rule that is set; a
+// collection without rules only holds its included products.
+type CollectionRules struct {
+	CategoryID         *uuid.UUID        `json:"category_id,omitempty"`
+	IncludeDescendants bool              `json:"include_descendants,omitempty"`
+	Status             string            `json:"status,omitempty" validate:"omitempty,oneof=active inactive out_of_stock"`
+	MinPrice           *float64          `json:"min_price,omitempty" validate:"omitempty,min=0" example:"0"`
+	MaxPrice           *float64          `json:"max_price,omitempty" validate:"omitempty,min=0" example:"50"`
+	MinStock           *int              `json:"min_stock,omitempty" validate:"omitempty,min=0"`
+	MaxStock           *int              `json:"max_stock,omitempty" validate:"omitempty,min=0"`
+	MinRating          *float64          `json:"min_rating,omitempty" validate:"omitempty,min=1,max=5" example:"4"`
+	Attributes         map[string]string `json:"attributes,omitempty" validate:"max=10"`
+	Tags               []string          `json:"tags,omitempty" validate:"max=20,dive,required,max=50"`
+}
+
+// IsEmpty reports whether no rule is set
+func (r CollectionRules) IsEmpty() bool {
+	return r.CategoryID == nil && r.Status == "" &&
+		r.MinPrice == nil && r.MaxPrice == nil &&
+		r.MinStock == nil && r.MaxStock == nil && r.MinRating == nil &&
+		len(r.Attributes) == 0 && len(r.Tags) == 0
+}
+
+// Collection is a rule-based product collection
+type Collection struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Slug        string          `json:"slug"`
+	Description string          `json:"description,omitempty"`
+	Rules       CollectionRules `json:"rules"`
+	SortBy      string          `json:"sort_by"`
+	SortOrder   string          `json:"sort_order"`
+	IsActive    bool            `json:"is_active"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// Collection creation/update input
+type CollectionInput struct {
+	Name        string          `json:"name" validate:"required,max=100" example:"Under $50 audio gear"`
+	Description string          `json:"description"`
+	Rules       CollectionRules `json:"rules"`
+	SortBy      string          `json:"sort_by,omitempty" validate:"omitempty,oneof=name price created_at stock_quantity sku" example:"price"`
+	SortOrder   string          `json:"sort_order,omitempty" validate:"omitempty,oneof=asc desc" example:"asc"`
+	IsActive    *bool           `json:"is_active,omitempty" example:"true"` // Defaults to true on create, unchanged on update when omitted
+}
+
+// Collection preview input, the rules of a collection being edited
+type CollectionPreviewInput struct {
+	Rules     CollectionRules `json:"rules"`
+	SortBy    string          `json:"sort_by,omitempty" validate:"omitempty,oneof=name price created_at stock_quantity sku" example:"price"`
+	SortOrder string          `json:"sort_order,omitempty" validate:"omitempty,oneof=asc desc" example:"asc"`
+}
+
+// CollectionOverride adds a product to a collection or keeps it out, whatever the rules
+type CollectionOverride struct {
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	SKU       string    `json:"sku" db:"sku"`
+	Name      string    `json:"name" db:"name"`
+	Mode      string    `json:"mode" db:"mode"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Collection override input
+type CollectionOverrideInput struct {
+	Mode string `json:"mode" validate:"required,oneof=include exclude" example:"include"`
+}
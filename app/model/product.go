@@ -15,13 +15,26 @@ type Product struct {
 	CostPrice     float64        `json:"cost_price,omitempty" db:"cost_price"`
 	StockQuantity int            `json:"stock_quantity" db:"stock_quantity"`
 	Status        string         `json:"status" db:"status"`
+	PublishStatus string         `json:"publish_status" db:"publish_status"`
+	PublishAt     *time.Time     `json:"publish_at,omitempty" db:"publish_at"`
+	PublishedAt   *time.Time     `json:"published_at,omitempty" db:"published_at"`
 	Attributes    map[string]any `json:"attributes,omitempty" db:"attributes"`
 	Categories    []Category     `json:"categories,omitempty"`
+	Rating        *RatingSummary `json:"rating,omitempty"`
 	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
 	IsDeleted     bool           `json:"is_deleted,omitempty" db:"is_deleted"`
 }
 
+// EffectivePrice returns the price a customer pays: the sale price when one is set
+// below the regular price, the regular price otherwise.
+func (p *Product) EffectivePrice() float64 {
+	if p.SalePrice > 0 && p.SalePrice < p.Price {
+		return p.SalePrice
+	}
+	return p.Price
+}
+
 // Product creation/update input
 type ProductInput struct {
 	Name          string         `json:"name" validate:"required"`
@@ -35,3 +48,19 @@ type ProductInput struct {
 	Attributes    map[string]any `json:"attributes,omitempty"`
 	CategoryIDs   []uuid.UUID    `json:"category_ids" validate:"required,min=1"`
 }
+
+// Product clone input, every field is optional
+type ProductCloneInput struct {
+	SKU              string `json:"sku,omitempty" validate:"max=50" example:"TSHIRT-RED-XL"`
+	Name             string `json:"name,omitempty" validate:"max=100" example:"T-Shirt red XL"`
+	IncludeRelations bool   `json:"include_relations" example:"true"`
+}
+
+// MaxProductBatchSize is the maximum number of IDs and SKUs accepted by a batch lookup
+const MaxProductBatchSize = 100
+
+// Product batch lookup input
+type ProductBatchInput struct {
+	IDs  []uuid.UUID `json:"ids" validate:"max=100"`
+	SKUs []string    `json:"skus" validate:"max=100,dive,required"`
+}
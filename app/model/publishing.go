@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Product publish statuses
+const (
+	PublishStatusDraft     = "draft"
+	PublishStatusInReview  = "in_review"
+	PublishStatusScheduled = "scheduled"
+	PublishStatusPublished = "published"
+	PublishStatusArchived  = "archived"
+)
+
+// publishTransitions lists for each publish status the statuses it can move to,
+// with the roles allowed to make the move. Editors prepare products, admins publish them.
+var publishTransitions = map[string]map[string][]string{
+	PublishStatusDraft: {
+		PublishStatusInReview: {"editor", "admin"},
+	},
+	PublishStatusInReview: {
+		PublishStatusDraft:     {"editor", "admin"},
+		PublishStatusScheduled: {"admin"},
+		PublishStatusPublished: {"admin"},
+	},
+	PublishStatusScheduled: {
+		PublishStatusDraft:     {"admin"},
+		PublishStatusPublished: {"admin"},
+	},
+	PublishStatusPublished: {
+		PublishStatusArchived: {"admin"},
+	},
+	PublishStatusArchived: {
+		PublishStatusDraft: {"admin"},
+	},
+}
+
+// PublishTransitionRoles returns the roles allowed to move a product or draft from one
+// publish status to another, or nil when the move is not part of the workflow
+func PublishTransitionRoles(from, to string) []string {
+	return publishTransitions[from][to]
+}
+
+// ProductDraft holds the pending edits of a published product. The live product
+// is left unchanged until the draft is published.
+type ProductDraft struct {
+	ProductID uuid.UUID    `json:"product_id" db:"product_id"`
+	Data      ProductInput `json:"data"`
+	Status    string       `json:"status" db:"status"`
+	PublishAt *time.Time   `json:"publish_at,omitempty" db:"publish_at"`
+	UpdatedBy *uuid.UUID   `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Publish status change input
+type PublishStatusInput struct {
+	Status    string     `json:"status" validate:"required,oneof=draft in_review scheduled published archived" example:"in_review"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Purchase order statuses
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderSent              = "sent"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+)
+
+type PurchaseOrder struct {
+	ID         uuid.UUID           `json:"id" db:"id"`
+	Number     string              `json:"number" db:"number"`
+	SupplierID uuid.UUID           `json:"supplier_id" db:"supplier_id"`
+	Status     string              `json:"status" db:"status"`
+	ExpectedAt *time.Time          `json:"expected_at,omitempty" db:"expected_at"`
+	Notes      string              `json:"notes,omitempty" db:"notes"`
+	CreatedBy  *uuid.UUID          `json:"created_by,omitempty" db:"created_by"`
+	SentAt     *time.Time          `json:"sent_at,omitempty" db:"sent_at"`
+	ReceivedAt *time.Time          `json:"received_at,omitempty" db:"received_at"`
+	Total      float64             `json:"total" db:"total"`
+	Lines      []PurchaseOrderLine `json:"lines,omitempty"`
+	CreatedAt  time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+type PurchaseOrderLine struct {
+	ID               uuid.UUID `json:"id" db:"id"`
+	PurchaseOrderID  uuid.UUID `json:"purchase_order_id" db:"purchase_order_id"`
+	ProductID        uuid.UUID `json:"product_id" db:"product_id"`
+	ProductSKU       string    `json:"product_sku" db:"product_sku"`
+	ProductName      string    `json:"product_name" db:"product_name"`
+	SupplierSKU      *string   `json:"supplier_sku,omitempty" db:"supplier_sku"`
+	QuantityOrdered  int       `json:"quantity_ordered" db:"quantity_ordered"`
+	QuantityReceived int       `json:"quantity_received" db:"quantity_received"`
+	UnitCost         float64   `json:"unit_cost" db:"unit_cost"`
+}
+
+// Purchase order creation/update input
+type PurchaseOrderInput struct {
+	SupplierID uuid.UUID                `json:"supplier_id" validate:"required"`
+	ExpectedAt *time.Time               `json:"expected_at,omitempty"`
+	Notes      string                   `json:"notes"`
+	Lines      []PurchaseOrderLineInput `json:"lines" validate:"required,min=1,dive"`
+}
+
+// Purchase order line input. Without a unit cost, the cost of the supplier link or
+// the cost price of the product is used.
+type PurchaseOrderLineInput struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,gt=0" example:"50"`
+	UnitCost  *float64  `json:"unit_cost,omitempty" validate:"omitempty,gte=0" example:"12.5"`
+}
+
+// Goods receipt input, the quantities received per product. Without a warehouse,
+// the goods go to the default warehouse.
+type GoodsReceiptInput struct {
+	WarehouseID *uuid.UUID              `json:"warehouse_id,omitempty"`
+	Lines       []GoodsReceiptLineInput `json:"lines" validate:"required,min=1,dive"`
+	Notes       string                  `json:"notes"`
+}
+
+type GoodsReceiptLineInput struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,gt=0" example:"20"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecentlyViewedItem struct {
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	ViewedAt  time.Time `json:"viewed_at" db:"viewed_at"`
+	Product   *Product  `json:"product,omitempty"`
+}
@@ -0,0 +1,12 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// Recommendation is a product recommended to a user or alongside another product
+type Recommendation struct {
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	Score     float64   `json:"score" db:"score"`
+	Product   *Product  `json:"product,omitempty"`
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// MarginReportFilter filters the margin reports. Products without a cost price are never included.
+// With a warehouse, the stock quantity and stock margin are those of that warehouse.
+type MarginReportFilter struct {
+	WarehouseID      *uuid.UUID
+	CategoryID       *uuid.UUID
+	Status           string
+	BelowCostOnly    bool
+	MinMarginPercent *float64
+	MaxMarginPercent *float64
+	SortBy           string
+	SortOrder        string
+}
+
+// ProductMargin is the unit margin of a product at its effective price
+type ProductMargin struct {
+	ProductID      uuid.UUID `json:"product_id" db:"product_id"`
+	SKU            string    `json:"sku" db:"sku"`
+	Name           string    `json:"name" db:"name"`
+	Status         string    `json:"status" db:"status"`
+	Price          float64   `json:"price" db:"price"`
+	SalePrice      *float64  `json:"sale_price,omitempty" db:"sale_price"`
+	EffectivePrice float64   `json:"effective_price" db:"effective_price"`
+	CostPrice      float64   `json:"cost_price" db:"cost_price"`
+	UnitMargin     float64   `json:"unit_margin" db:"unit_margin"`
+	MarginPercent  float64   `json:"margin_percent" db:"margin_percent"`
+	StockQuantity  int       `json:"stock_quantity" db:"stock_quantity"`
+	StockMargin    float64   `json:"stock_margin" db:"stock_margin"`
+	BelowCost      bool      `json:"below_cost" db:"below_cost"`
+}
+
+// CategoryMargin aggregates the product margins of a category
+type CategoryMargin struct {
+	CategoryID           uuid.UUID `json:"category_id" db:"category_id"`
+	Name                 string    `json:"name" db:"name"`
+	ProductCount         int       `json:"product_count" db:"product_count"`
+	AverageUnitMargin    float64   `json:"average_unit_margin" db:"average_unit_margin"`
+	AverageMarginPercent float64   `json:"average_margin_percent" db:"average_margin_percent"`
+	MinMarginPercent     float64   `json:"min_margin_percent" db:"min_margin_percent"`
+	MaxMarginPercent     float64   `json:"max_margin_percent" db:"max_margin_percent"`
+	StockMargin          float64   `json:"stock_margin" db:"stock_margin"`
+	BelowCostCount       int       `json:"below_cost_count" db:"below_cost_count"`
+}
+
+// MarginSummary sums up a margin report
+type MarginSummary struct {
+	ProductCount         int     `json:"product_count" db:"product_count"`
+	BelowCostCount       int     `json:"below_cost_count" db:"below_cost_count"`
+	AverageMarginPercent float64 `json:"average_margin_percent" db:"average_margin_percent"`
+	StockMargin          float64 `json:"stock_margin" db:"stock_margin"`
+}
+
+// StockReportFilter filters the stock report
+type StockReportFilter struct {
+	WarehouseID *uuid.UUID
+	CategoryID  *uuid.UUID
+	Status      string
+	Search      string
+	MaxQuantity *int // low stock threshold
+	SortBy      string
+	SortOrder   string
+}
+
+// StockLevel is the stock of a product in a warehouse, valued at cost price
+type StockLevel struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id" db:"warehouse_id"`
+	WarehouseCode string    `json:"warehouse_code" db:"warehouse_code"`
+	ProductID     uuid.UUID `json:"product_id" db:"product_id"`
+	SKU           string    `json:"sku" db:"sku"`
+	Name          string    `json:"name" db:"name"`
+	Status        string    `json:"status" db:"status"`
+	Quantity      int       `json:"quantity" db:"quantity"`
+	CostPrice     float64   `json:"cost_price" db:"cost_price"`
+	StockValue    float64   `json:"stock_value" db:"stock_value"`
+}
+
+// StockSummary sums up a stock report
+type StockSummary struct {
+	RowCount      int     `json:"row_count" db:"row_count"`
+	TotalQuantity int     `json:"total_quantity" db:"total_quantity"`
+	StockValue    float64 `json:"stock_value" db:"stock_value"`
+	OutOfStock    int     `json:"out_of_stock" db:"out_of_stock"`
+}
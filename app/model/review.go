@@ -1,25 +1,32 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Review struct {
-	ID                 uuid.UUID `json:"id" db:"id"`
-	ProductID          uuid.UUID `json:"product_id" db:"product_id"`
-	UserID             uuid.UUID `json:"user_id" db:"user_id"`
-	Rating             int       `json:"rating" db:"rating"`
-	Title              string    `json:"title,omitempty" db:"title"`
-	Comment            string    `json:"comment" db:"comment"`
-	IsVerifiedPurchase bool      `json:"is_verified_purchase" db:"is_verified_purchase"`
-	HelpfulVotes       int       `json:"helpful_votes" db:"helpful_votes"`
-	Username           string    `json:"username,omitempty"`
-	ProductName        string    `json:"product_name,omitempty" db:"product_name"`
-	CreatedAt          time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
-	IsDeleted          bool      `json:"is_deleted,omitempty" db:"is_deleted"`
+	ID                 uuid.UUID     `json:"id" db:"id"`
+	ProductID          uuid.UUID     `json:"product_id" db:"product_id"`
+	UserID             uuid.UUID     `json:"user_id" db:"user_id"`
+	Rating             int           `json:"rating" db:"rating"`
+	Title              string        `json:"title,omitempty" db:"title"`
+	Comment            string        `json:"comment" db:"comment"`
+	IsVerifiedPurchase bool          `json:"is_verified_purchase" db:"is_verified_purchase"`
+	HelpfulVotes       int           `json:"helpful_votes" db:"helpful_votes"`
+	NotHelpfulVotes    int           `json:"not_helpful_votes" db:"not_helpful_votes"`
+	ModerationStatus   string        `json:"moderation_status" db:"moderation_status"`
+	RejectionReason    *string       `json:"rejection_reason,omitempty" db:"rejection_reason"`
+	ModeratedBy        *uuid.UUID    `json:"moderated_by,omitempty" db:"moderated_by"`
+	ModeratedAt        *time.Time    `json:"moderated_at,omitempty" db:"moderated_at"`
+	Photos             []ReviewPhoto `json:"photos" db:"-"`
+	Username           string        `json:"username,omitempty"`
+	ProductName        string        `json:"product_name,omitempty" db:"product_name"`
+	CreatedAt          time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time     `json:"updated_at" db:"updated_at"`
+	IsDeleted          bool          `json:"is_deleted,omitempty" db:"is_deleted"`
 }
 
 // Review creation input
@@ -29,3 +36,99 @@ type ReviewInput struct {
 	Title     string    `json:"title"`
 	Comment   string    `json:"comment" validate:"required"`
 }
+
+// Moderation statuses of a review. Only approved reviews are public.
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// ReviewModerationFilter filters the moderation queue
+type ReviewModerationFilter struct {
+	Status        string
+	ProductID     *uuid.UUID
+	UserID        *uuid.UUID
+	Rating        *int
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// ReviewModerationInput approves or rejects reviews in bulk. A rejection needs a reason.
+type ReviewModerationInput struct {
+	ReviewIDs []uuid.UUID `json:"review_ids" validate:"required,min=1,max=100"`
+	Status    string      `json:"status" validate:"required,oneof=approved rejected"`
+	Reason    string      `json:"reason" validate:"required_if=Status rejected,max=500"`
+}
+
+// ReviewModeration is the outcome of a bulk moderation
+type ReviewModeration struct {
+	Status   string      `json:"status"`
+	Updated  []uuid.UUID `json:"updated"`
+	NotFound []uuid.UUID `json:"not_found"`
+}
+
+// Image types accepted for review photos, with their file extension
+var ReviewPhotoTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// ReviewPhoto is a picture attached to a review
+type ReviewPhoto struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	ReviewID    uuid.UUID `json:"review_id" db:"review_id"`
+	StorageKey  string    `json:"-" db:"storage_key"`
+	URL         string    `json:"url"` // Public URL, depends on the storage driver
+	ContentType string    `json:"content_type" db:"content_type"`
+	SizeBytes   int       `json:"size_bytes" db:"size_bytes"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// ReviewPhotoURL returns the public URL of a stored photo. The server sets it from the
+// storage driver, so every response holding review photos carries their URLs.
+var ReviewPhotoURL = func(storageKey string) string { return storageKey }
+
+// MarshalJSON encodes the photo with its public URL
+func (p ReviewPhoto) MarshalJSON() ([]byte, error) {
+	type reviewPhoto ReviewPhoto // Without the MarshalJSON method
+	photo := reviewPhoto(p)
+	photo.URL = ReviewPhotoURL(p.StorageKey)
+	return json.Marshal(photo)
+}
+
+// Sort orders of product reviews
+const (
+	ReviewSortNewest      = "newest"
+	ReviewSortMostHelpful = "most_helpful"
+)
+
+// ReviewVoteInput is the vote of a user on a review. Helpful defaults to true.
+type ReviewVoteInput struct {
+	Helpful *bool `json:"helpful"`
+}
+
+// ReviewVote holds the vote counters of a review and the vote of the current user
+type ReviewVote struct {
+	ReviewID        uuid.UUID `json:"review_id" db:"review_id"`
+	HelpfulVotes    int       `json:"helpful_votes" db:"helpful_votes"`
+	NotHelpfulVotes int       `json:"not_helpful_votes" db:"not_helpful_votes"`
+	Helpful         *bool     `json:"helpful"`
+}
+
+// RatingStats holds the aggregated rating of a product
+type RatingStats struct {
+	ProductID     uuid.UUID `json:"product_id" db:"product_id"`
+	AverageRating float64   `json:"average_rating" db:"average_rating"`
+	ReviewCount   int       `json:"review_count" db:"review_count"`
+}
+
+// RatingSummary holds the approved reviews of a product: their average rating, count
+// and the number of reviews per star, keyed "1" to "5"
+type RatingSummary struct {
+	ProductID     uuid.UUID      `json:"product_id"`
+	AverageRating float64        `json:"average_rating"`
+	ReviewCount   int            `json:"review_count"`
+	Histogram     map[string]int `json:"histogram"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedSearchFilters are the GetProducts filters of a saved search
+type SavedSearchFilters struct {
+	Search             string     `json:"search,omitempty" validate:"max=100"`
+	CategoryID         *uuid.UUID `json:"category_id,omitempty"`
+	IncludeDescendants bool       `json:"include_descendants,omitempty"`
+	Status             string     `json:"status,omitempty" validate:"omitempty,oneof=active inactive out_of_stock"`
+	MinPrice           *float64   `json:"min_price,omitempty" validate:"omitempty,min=0"`
+	MaxPrice           *float64   `json:"max_price,omitempty" validate:"omitempty,min=0"`
+	MinStock           *int       `json:"min_stock,omitempty" validate:"omitempty,min=0"`
+	MaxStock           *int       `json:"max_stock,omitempty" validate:"omitempty,min=0"`
+}
+
+// SavedSearch is a named filter set saved by a user
+type SavedSearch struct {
+	ID            uuid.UUID          `json:"id" db:"id"`
+	UserID        uuid.UUID          `json:"user_id" db:"user_id"`
+	Name          string             `json:"name" db:"name"`
+	Filters       SavedSearchFilters `json:"filters"`
+	Notify        bool               `json:"notify" db:"notify"`
+	LastCheckedAt time.Time          `json:"last_checked_at" db:"last_checked_at"`
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// Saved search creation/update input
+type SavedSearchInput struct {
+	Name    string             `json:"name" validate:"required,max=100" example:"Cheap headphones"`
+	Filters SavedSearchFilters `json:"filters"`
+	Notify  bool               `json:"notify" example:"true"`
+}
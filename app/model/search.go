@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Suggestion is a search-as-you-type suggestion
+type Suggestion struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	Text       string    `json:"text" db:"text"`
+	Popularity int       `json:"popularity" db:"popularity"`
+}
+
+// SearchSynonym is a group of terms that match each other at search time
+type SearchSynonym struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Terms     []string  `json:"terms"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Search synonym creation/update input
+type SearchSynonymInput struct {
+	Terms []string `json:"terms" validate:"required,min=2,dive,required,max=100" example:"laptop,notebook"`
+}
+
+// Search result click input
+type SearchClickInput struct {
+	Query     string    `json:"query" validate:"required,max=100"`
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+}
+
+// SearchQueryStat aggregates the searches of one normalized query
+type SearchQueryStat struct {
+	Query              string    `json:"query" db:"query"`
+	Searches           int       `json:"searches" db:"searches"`
+	ZeroResultSearches int       `json:"zero_result_searches" db:"zero_result_searches"`
+	AverageResults     float64   `json:"average_results" db:"average_results"`
+	Clicks             int       `json:"clicks" db:"clicks"`
+	LastSearchedAt     time.Time `json:"last_searched_at" db:"last_searched_at"`
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock count statuses
+const (
+	StockCountOpen      = "open"
+	StockCountSubmitted = "submitted"
+	StockCountApproved  = "approved"
+	StockCountCancelled = "cancelled"
+)
+
+// StockCount is a cycle count session of a set of products in a warehouse
+type StockCount struct {
+	ID            uuid.UUID        `json:"id" db:"id"`
+	WarehouseID   uuid.UUID        `json:"warehouse_id" db:"warehouse_id"`
+	WarehouseCode string           `json:"warehouse_code" db:"warehouse_code"`
+	Status        string           `json:"status" db:"status"`
+	Notes         string           `json:"notes,omitempty" db:"notes"`
+	CreatedBy     *uuid.UUID       `json:"created_by,omitempty" db:"created_by"`
+	SubmittedAt   *time.Time       `json:"submitted_at,omitempty" db:"submitted_at"`
+	ApprovedBy    *uuid.UUID       `json:"approved_by,omitempty" db:"approved_by"`
+	ApprovedAt    *time.Time       `json:"approved_at,omitempty" db:"approved_at"`
+	LineCount     int              `json:"line_count" db:"line_count"`
+	CountedCount  int              `json:"counted_count" db:"counted_count"`
+	Lines         []StockCountLine `json:"lines,omitempty"`
+	CreatedAt     time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// StockCountLine is a product of a count session. The expected quantity is the
+// system stock when the product was counted.
+type StockCountLine struct {
+	ProductID        uuid.UUID  `json:"product_id" db:"product_id"`
+	ProductSKU       string     `json:"product_sku" db:"product_sku"`
+	ProductName      string     `json:"product_name" db:"product_name"`
+	ExpectedQuantity *int       `json:"expected_quantity,omitempty" db:"expected_quantity"`
+	CountedQuantity  *int       `json:"counted_quantity,omitempty" db:"counted_quantity"`
+	CountedBy        *uuid.UUID `json:"counted_by,omitempty" db:"counted_by"`
+	CountedAt        *time.Time `json:"counted_at,omitempty" db:"counted_at"`
+}
+
+// Stock count session input. Without a warehouse, the default warehouse is counted.
+type StockCountInput struct {
+	WarehouseID *uuid.UUID  `json:"warehouse_id,omitempty"`
+	ProductIDs  []uuid.UUID `json:"product_ids" validate:"required,min=1,max=500"`
+	Notes       string      `json:"notes"`
+}
+
+// Counted quantities input. Counting a product again replaces its count.
+type StockCountEntriesInput struct {
+	Lines []StockCountEntryInput `json:"lines" validate:"required,min=1,dive"`
+}
+
+type StockCountEntryInput struct {
+	ProductID       uuid.UUID `json:"product_id" validate:"required"`
+	CountedQuantity int       `json:"counted_quantity" validate:"gte=0" example:"18"`
+}
+
+// StockVariance is the difference between the counted and the system quantity of a product
+type StockVariance struct {
+	ProductID        uuid.UUID `json:"product_id" db:"product_id"`
+	SKU              string    `json:"sku" db:"sku"`
+	Name             string    `json:"name" db:"name"`
+	ExpectedQuantity int       `json:"expected_quantity" db:"expected_quantity"`
+	CountedQuantity  int       `json:"counted_quantity" db:"counted_quantity"`
+	Variance         int       `json:"variance" db:"variance"`
+	CostPrice        float64   `json:"cost_price" db:"cost_price"`
+	VarianceValue    float64   `json:"variance_value" db:"variance_value"`
+}
+
+// StockVarianceSummary sums up the variance report of a count session
+type StockVarianceSummary struct {
+	CountedCount  int     `json:"counted_count" db:"counted_count"`
+	VarianceCount int     `json:"variance_count" db:"variance_count"`
+	NetVariance   int     `json:"net_variance" db:"net_variance"`
+	VarianceValue float64 `json:"variance_value" db:"variance_value"`
+}
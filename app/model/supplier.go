@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Supplier struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Email     string    `json:"email,omitempty" db:"email"`
+	Phone     string    `json:"phone,omitempty" db:"phone"`
+	Address   string    `json:"address,omitempty" db:"address"`
+	IsActive  bool      `json:"is_active" db:"is_active"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	IsDeleted bool      `json:"is_deleted,omitempty" db:"is_deleted"`
+}
+
+// Supplier creation/update input
+type SupplierInput struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	Email    string `json:"email" validate:"omitempty,email,max=100"`
+	Phone    string `json:"phone" validate:"max=30"`
+	Address  string `json:"address"`
+	IsActive *bool  `json:"is_active,omitempty" example:"true"` // Defaults to true on create, unchanged on update when omitted
+}
+
+// ProductSupplier links a product to a supplier that delivers it
+type ProductSupplier struct {
+	ProductID    uuid.UUID `json:"product_id" db:"product_id"`
+	SupplierID   uuid.UUID `json:"supplier_id" db:"supplier_id"`
+	SupplierSKU  string    `json:"supplier_sku,omitempty" db:"supplier_sku"`
+	LeadTimeDays int       `json:"lead_time_days" db:"lead_time_days"`
+	UnitCost     *float64  `json:"unit_cost,omitempty" db:"unit_cost"`
+	IsPreferred  bool      `json:"is_preferred" db:"is_preferred"`
+	ProductSKU   string    `json:"product_sku" db:"product_sku"`
+	ProductName  string    `json:"product_name" db:"product_name"`
+	SupplierName string    `json:"supplier_name" db:"supplier_name"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Product supplier link input
+type ProductSupplierInput struct {
+	SupplierSKU  string   `json:"supplier_sku" validate:"max=50" example:"ACME-4411"`
+	LeadTimeDays int      `json:"lead_time_days" validate:"gte=0" example:"14"`
+	UnitCost     *float64 `json:"unit_cost,omitempty" validate:"omitempty,gte=0" example:"12.5"`
+	IsPreferred  bool     `json:"is_preferred"`
+}
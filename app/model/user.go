@@ -29,7 +29,7 @@ type RegisterUser struct {
 	Email    string `json:"email" validate:"required,email" example:"john@example.com"`
 	Password string `json:"password" validate:"required,min=8" example:"password123"`
 	FullName string `json:"full_name" validate:"required" example:"John Doe"`
-	Role     string `json:"role" validate:"oneof=user admin" example:"user"`
+	Role     string `json:"role" validate:"oneof=user editor admin" example:"user"`
 }
 
 // UpdateUser represents the data required to register a new user
@@ -38,7 +38,7 @@ type UpdateUser struct {
 	UserName string `json:"username" validate:"required,min=3,max=50" example:"johndoe"`
 	Email    string `json:"email" validate:"required,email" example:"john@example.com"`
 	FullName string `json:"full_name" validate:"required" example:"John Doe"`
-	Role     string `json:"role" validate:"oneof=user admin" example:"user"`
+	Role     string `json:"role" validate:"oneof=user editor admin" example:"user"`
 }
 
 // LoginUser represents the data required to login
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Inventory movement types
+const (
+	MovementPurchase    = "purchase"
+	MovementAdjustment  = "adjustment"
+	MovementTransferOut = "transfer_out"
+	MovementTransferIn  = "transfer_in"
+)
+
+type Warehouse struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Code      string    `json:"code" db:"code"`
+	Name      string    `json:"name" db:"name"`
+	Address   string    `json:"address,omitempty" db:"address"`
+	IsActive  bool      `json:"is_active" db:"is_active"`
+	IsDefault bool      `json:"is_default" db:"is_default"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	IsDeleted bool      `json:"is_deleted,omitempty" db:"is_deleted"`
+}
+
+// Warehouse creation/update input. Making a warehouse the default one takes the
+// flag away from the previous default warehouse.
+type WarehouseInput struct {
+	Code      string `json:"code" validate:"required,max=20" example:"EAST"`
+	Name      string `json:"name" validate:"required,max=100" example:"East warehouse"`
+	Address   string `json:"address"`
+	IsActive  *bool  `json:"is_active,omitempty" example:"true"` // Defaults to true on create, unchanged on update when omitted
+	IsDefault bool   `json:"is_default"`
+}
+
+// WarehouseStock is the stock level of a product in a warehouse
+type WarehouseStock struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id" db:"warehouse_id"`
+	WarehouseCode string    `json:"warehouse_code" db:"warehouse_code"`
+	WarehouseName string    `json:"warehouse_name" db:"warehouse_name"`
+	ProductID     uuid.UUID `json:"product_id" db:"product_id"`
+	ProductSKU    string    `json:"product_sku" db:"product_sku"`
+	ProductName   string    `json:"product_name" db:"product_name"`
+	Quantity      int       `json:"quantity" db:"quantity"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Warehouse stock level input, recorded as an adjustment movement
+type WarehouseStockInput struct {
+	Quantity int    `json:"quantity" validate:"gte=0" example:"40"`
+	Notes    string `json:"notes"`
+}
+
+// Stock transfer input, moving stock of a product between two different warehouses
+type StockTransferInput struct {
+	FromWarehouseID uuid.UUID `json:"from_warehouse_id" validate:"required"`
+	ToWarehouseID   uuid.UUID `json:"to_warehouse_id" validate:"required"`
+	ProductID       uuid.UUID `json:"product_id" validate:"required"`
+	Quantity        int       `json:"quantity" validate:"required,gt=0" example:"10"`
+	Notes           string    `json:"notes"`
+}
+
+// StockTransfer is a completed transfer. Its ID is the reference_id of the paired
+// transfer_out and transfer_in inventory movements.
+type StockTransfer struct {
+	ID              uuid.UUID `json:"id"`
+	FromWarehouseID uuid.UUID `json:"from_warehouse_id"`
+	ToWarehouseID   uuid.UUID `json:"to_warehouse_id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	Quantity        int       `json:"quantity"`
+	CreatedAt       time.Time `json:"created_at"`
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+
+	"github.com/google/uuid"
+)
+
+type barcodeRepository struct {
+	db *database.DB
+}
+
+func NewBarcodeRepository(db *database.DB) BarcodeRepository {
+	return &barcodeRepository{
+		db: db,
+	}
+}
+
+// Create adds a barcode to a product. A primary barcode takes the flag away from
+// the other barcodes of the product.
+func (r *barcodeRepository) Create(barcode *model.ProductBarcode) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if barcode.IsPrimary {
+		if _, err := tx.Exec(`UPDATE product_barcodes SET is_primary = FALSE WHERE product_id = $1`, barcode.ProductID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO product_barcodes (id, product_id, code, type, gtin, is_primary, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = tx.Exec(
+		query,
+		barcode.ID,
+		barcode.ProductID,
+		barcode.Code,
+		barcode.Type,
+		barcode.GTIN,
+		barcode.IsPrimary,
+		barcode.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByGTIN gets the barcode with the GTIN, whatever the product
+func (r *barcodeRepository) GetByGTIN(gtin string) (*model.ProductBarcode, error) {
+	var barcode model.ProductBarcode
+
+	if err := r.db.Get(&barcode, `SELECT * FROM product_barcodes WHERE gtin = $1`, gtin); err != nil {
+		return nil, err
+	}
+
+	return &barcode, nil
+}
+
+// GetByProductID lists the barcodes of a product, the primary barcode first
+func (r *barcodeRepository) GetByProductID(productID uuid.UUID) ([]model.ProductBarcode, error) {
+	barcodes := []model.ProductBarcode{}
+
+	query := `SELECT * FROM product_barcodes WHERE product_id = $1 ORDER BY is_primary DESC, created_at ASC`
+	if err := r.db.Select(&barcodes, query, productID); err != nil {
+		return nil, err
+	}
+
+	return barcodes, nil
+}
+
+// Delete removes a barcode from a product
+func (r *barcodeRepository) Delete(productID, id uuid.UUID) error {
+	return r.db.QueryRow("DELETE FROM product_barcodes WHERE id = $1 AND product_id = $2 RETURNING id", id, productID).Scan(&id)
+}
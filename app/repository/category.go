@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"golang-test1/app/model"
 	"golang-test1/platform/database"
 	"time"
@@ -9,8 +13,25 @@ import (
 
 // Common errors
 var (
-	ErrCategoryHasProducts = NewError("category has products")
-	ErrCategoryHasChildren = NewError("category has child categories")
+	ErrCategoryHasProducts   = NewError("category has products")
+	ErrCategoryHasChildren   = NewError("category has child categories")
+	ErrCategoryInCollections = NewError("category is used by collection rules")
+)
+
+// Category hierarchy errors
+var (
+	ErrParentCategoryNotFound = NewError("parent category not found")
+	ErrParentCategoryInactive = NewError("parent category is inactive")
+	ErrCategoryCycle          = NewError("category cannot be moved below itself")
+	ErrCategoryTooDeep        = NewError("category tree would be too deep")
+	ErrCategoryOrderMismatch  = NewError("category ids must list every sibling exactly once")
+	ErrCategoryMergeSelf      = NewError("category cannot be merged into itself")
+	ErrMergeTargetNotFound    = NewError("target category not found")
+)
+
+// Merchandising errors
+var (
+	ErrProductNotInCategory = NewError("product is not in the category")
 )
 
 func NewError(message string) error {
@@ -29,13 +50,29 @@ type categoryRepository struct {
 	db *database.DB
 }
 
+// Create creates a category. The parent is checked like a move, so a new category
+// cannot be placed below a missing or inactive parent or too deep in the tree.
 func (c categoryRepository) Create(category *model.Category) error {
+	tx, err := c.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockCategories(tx); err != nil {
+		return err
+	}
+
+	if err := checkCategoryParent(tx, category.ID, category.ParentID); err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO categories (id, name, slug, description, parent_id, is_active, display_order, created_at, updated_at, is_deleted)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
-	_, err := c.db.Exec(
+	_, err = tx.Exec(
 		query,
 		category.ID,
 		category.Name,
@@ -48,8 +85,11 @@ func (c categoryRepository) Create(category *model.Category) error {
 		category.UpdatedAt,
 		false,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return tx.Commit()
 }
 func (r *categoryRepository) GetByID(id uuid.UUID) (*model.Category, error) {
 	var category model.Category
@@ -60,13 +100,19 @@ func (r *categoryRepository) GetByID(id uuid.UUID) (*model.Category, error) {
 		return nil, err
 	}
 
-	// Get product count
+	// Get product counts
 	count, err := r.GetProductCount(id)
 	if err != nil {
 		return nil, err
 	}
 	category.ProductCount = count
 
+	count, err = r.GetSubtreeProductCount(id)
+	if err != nil {
+		return nil, err
+	}
+	category.SubtreeProductCount = count
+
 	return &category, nil
 }
 
@@ -79,17 +125,45 @@ func (r *categoryRepository) GetBySlug(slug string) (*model.Category, error) {
 		return nil, err
 	}
 
-	// Get product count
+	// Get product counts
 	count, err := r.GetProductCount(category.ID)
 	if err != nil {
 		return nil, err
 	}
 	category.ProductCount = count
 
+	count, err = r.GetSubtreeProductCount(category.ID)
+	if err != nil {
+		return nil, err
+	}
+	category.SubtreeProductCount = count
+
 	return &category, nil
 }
 
+// Update updates a category. A new parent is checked like a move, so the
+// hierarchy cannot be broken through a plain update.
 func (r *categoryRepository) Update(category *model.Category) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockCategories(tx); err != nil {
+		return err
+	}
+
+	var parentID *uuid.UUID
+	if err := tx.Get(&parentID, `SELECT parent_id FROM categories WHERE id = $1`, category.ID); err != nil {
+		return err
+	}
+	if !sameParent(parentID, category.ParentID) {
+		if err := checkCategoryParent(tx, category.ID, category.ParentID); err != nil {
+			return err
+		}
+	}
+
 	category.UpdatedAt = time.Now()
 
 	query := `
@@ -98,7 +172,7 @@ func (r *categoryRepository) Update(category *model.Category) error {
 		WHERE id = $8
 	`
 
-	_, err := r.db.Exec(
+	_, err = tx.Exec(
 		query,
 		category.Name,
 		category.Slug,
@@ -109,10 +183,205 @@ func (r *categoryRepository) Update(category *model.Category) error {
 		category.UpdatedAt,
 		category.ID,
 	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
 
+// lockCategories serialises changes to the hierarchy, so concurrent moves cannot
+// build a cycle between them. Reads are not blocked.
+func lockCategories(tx *sqlx.Tx) error {
+	_, err := tx.Exec(`LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE`)
 	return err
 }
 
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// checkCategoryParent checks that the category can be placed below the parent: the
+// parent must be active and must not be the category or one of its descendants, and
+// the moved subtree must stay within model.MaxCategoryDepth levels.
+func checkCategoryParent(tx *sqlx.Tx, id uuid.UUID, parentID *uuid.UUID) error {
+	parentDepth := -1
+	if parentID != nil {
+		var parent struct {
+			IsActive  bool `db:"is_active"`
+			IsDeleted bool `db:"is_deleted"`
+		}
+		err := tx.Get(&parent, `SELECT is_active, is_deleted FROM categories WHERE id = $1`, *parentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrParentCategoryNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if parent.IsDeleted {
+			return ErrParentCategoryNotFound
+		}
+		if !parent.IsActive {
+			return ErrParentCategoryInactive
+		}
+
+		// The parent must not lie in the subtree of the category
+		var inSubtree bool
+		query := `SELECT EXISTS (SELECT 1 FROM category_closure WHERE ancestor_id = $1 AND descendant_id = $2)`
+		if err := tx.Get(&inSubtree, query, id, *parentID); err != nil {
+			return err
+		}
+		if inSubtree {
+			return ErrCategoryCycle
+		}
+
+		query = `SELECT COALESCE(MAX(depth), 0) FROM category_closure WHERE descendant_id = $1`
+		if err := tx.Get(&parentDepth, query, *parentID); err != nil {
+			return err
+		}
+	}
+
+	// Levels below the category, which move along with it
+	var height int
+	query := `SELECT COALESCE(MAX(depth), 0) FROM category_closure WHERE ancestor_id = $1`
+	if err := tx.Get(&height, query, id); err != nil {
+		return err
+	}
+	if parentDepth+1+height >= model.MaxCategoryDepth {
+		return ErrCategoryTooDeep
+	}
+
+	return nil
+}
+
+// siblingIDs gets the children of a parent, or the top categories without one, in display order
+func siblingIDs(tx *sqlx.Tx, parentID *uuid.UUID, excludeID uuid.UUID) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	query := `
+		SELECT id FROM categories
+		WHERE parent_id IS NOT DISTINCT FROM $1 AND is_deleted = FALSE AND id <> $2
+		ORDER BY display_order ASC, name ASC
+	`
+	err := tx.Select(&ids, query, parentID, excludeID)
+	return ids, err
+}
+
+// renumberCategories sets the display order of the categories to their position in the list
+func renumberCategories(tx *sqlx.Tx, ids []uuid.UUID, updatedAt time.Time) error {
+	query := `
+		UPDATE categories SET display_order = $1, updated_at = $2
+		WHERE id = $3 AND display_order IS DISTINCT FROM $1
+	`
+	for i, id := range ids {
+		if _, err := tx.Exec(query, i, updatedAt, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Move places a category below a new parent, or at the top without one, at the given
+// position among its new siblings or else last. The siblings at both the old and the
+// new place are renumbered.
+func (r *categoryRepository) Move(id uuid.UUID, parentID *uuid.UUID, position *int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockCategories(tx); err != nil {
+		return err
+	}
+
+	var oldParentID *uuid.UUID
+	if err := tx.Get(&oldParentID, `SELECT parent_id FROM categories WHERE id = $1`, id); err != nil {
+		return err
+	}
+	if !sameParent(oldParentID, parentID) {
+		if err := checkCategoryParent(tx, id, parentID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	if _, err := tx.Exec(`UPDATE categories SET parent_id = $1, updated_at = $2 WHERE id = $3`, parentID, now, id); err != nil {
+		return err
+	}
+
+	// Close the gap left at the old place
+	if !sameParent(oldParentID, parentID) {
+		oldSiblings, err := siblingIDs(tx, oldParentID, id)
+		if err != nil {
+			return err
+		}
+		if err := renumberCategories(tx, oldSiblings, now); err != nil {
+			return err
+		}
+	}
+
+	siblings, err := siblingIDs(tx, parentID, id)
+	if err != nil {
+		return err
+	}
+	index := len(siblings)
+	if position != nil && *position < index {
+		index = *position
+	}
+	ordered := make([]uuid.UUID, 0, len(siblings)+1)
+	ordered = append(ordered, siblings[:index]...)
+	ordered = append(ordered, id)
+	ordered = append(ordered, siblings[index:]...)
+
+	if err := renumberCategories(tx, ordered, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Reorder sets the display order of the children of a parent, or of the top categories
+// without one. The ids must list every one of them exactly once.
+func (r *categoryRepository) Reorder(parentID *uuid.UUID, ids []uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockCategories(tx); err != nil {
+		return err
+	}
+
+	siblings, err := siblingIDs(tx, parentID, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	if len(siblings) != len(ids) {
+		return ErrCategoryOrderMismatch
+	}
+
+	remaining := make(map[uuid.UUID]bool, len(siblings))
+	for _, id := range siblings {
+		remaining[id] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return ErrCategoryOrderMismatch
+		}
+		delete(remaining, id)
+	}
+
+	if err := renumberCategories(tx, ids, time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (r *categoryRepository) Delete(id uuid.UUID) error {
 	// Check if category has products
 	var count int
@@ -135,6 +404,16 @@ func (r *categoryRepository) Delete(id uuid.UUID) error {
 		return ErrCategoryHasChildren
 	}
 
+	// Check if collections select products by the category
+	err = r.db.Get(&count, "SELECT COUNT(*) FROM collections WHERE rules->>'category_id' = $1", id.String())
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return ErrCategoryInCollections
+	}
+
 	// Delete category
 	_, err = r.db.Exec("DELETE FROM categories WHERE id = $1", id)
 	return err
@@ -149,22 +428,410 @@ func (r *categoryRepository) List() ([]model.Category, error) {
 		return nil, err
 	}
 
-	// Get product count for each category
+	if err := r.attachProductCounts(categories); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// publishedProductCategories is product_categories limited to published products, for
+// the product counts shown to the public
+const publishedProductCategories = `(
+	SELECT pc.product_id, pc.category_id FROM product_categories pc
+	JOIN products p ON p.id = pc.product_id
+	WHERE p.publish_status = 'published' AND p.is_deleted = FALSE
+)`
+
+// attachProductCounts sets the product counts of the categories, of the category itself
+// and of its subtree, in one query
+func (r *categoryRepository) attachProductCounts(categories []model.Category) error {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(categories))
+	for i, category := range categories {
+		ids[i] = category.ID
+	}
+
+	type countScan struct {
+		CategoryID          uuid.UUID `db:"category_id"`
+		ProductCount        int       `db:"product_count"`
+		SubtreeProductCount int       `db:"subtree_product_count"`
+	}
+
+	var rows []countScan
+
+	query := `
+		SELECT cc.ancestor_id AS category_id,
+		       COUNT(DISTINCT pc.product_id) FILTER (WHERE cc.depth = 0) AS product_count,
+		       COUNT(DISTINCT pc.product_id) AS subtree_product_count
+		FROM category_closure cc
+		JOIN ` + publishedProductCategories + ` pc ON pc.category_id = cc.descendant_id
+		WHERE cc.ancestor_id = ANY($1::uuid[])
+		GROUP BY cc.ancestor_id
+	`
+
+	if err := r.db.Select(&rows, query, ids); err != nil {
+		return err
+	}
+
+	counts := make(map[uuid.UUID]countScan, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row
+	}
+
 	for i := range categories {
-		count, err := r.GetProductCount(categories[i].ID)
-		if err != nil {
-			return nil, err
-		}
-		categories[i].ProductCount = count
+		count := counts[categories[i].ID]
+		categories[i].ProductCount = count.ProductCount
+		categories[i].SubtreeProductCount = count.SubtreeProductCount
+	}
+
+	return nil
+}
+
+// categoryTreeColumns lists the category columns, for recursive queries that add their own
+const categoryTreeColumns = `c.id, c.name, c.slug, c.description, c.parent_id, c.is_active, c.display_order,
+	c.created_at, c.updated_at, c.is_deleted`
+
+// GetTree gets the category tree from the top categories down, ordered so that parents
+// come before their children. maxDepth limits the levels below the top categories, 0 for
+// no limit. With activeOnly, inactive categories are left out together with their
+// branch. Subtree product counts always cover the full subtree, whatever the depth limit.
+func (r *categoryRepository) GetTree(maxDepth int, activeOnly bool) ([]model.CategoryTreeRow, error) {
+	rows := []model.CategoryTreeRow{}
+
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT ` + categoryTreeColumns + `, 0 AS depth, ARRAY[c.id] AS path
+			FROM categories c
+			WHERE c.parent_id IS NULL AND c.is_deleted = FALSE AND ($1 = FALSE OR c.is_active)
+			UNION ALL
+			SELECT ` + categoryTreeColumns + `, t.depth + 1, t.path || c.id
+			FROM categories c
+			JOIN tree t ON c.parent_id = t.id
+			WHERE c.is_deleted = FALSE AND ($1 = FALSE OR c.is_active)
+			  AND ($2 = 0 OR t.depth < $2)
+			  AND NOT c.id = ANY(t.path)
+		)
+		SELECT t.id, t.name, t.slug, t.description, t.parent_id, t.is_active, t.display_order,
+		       t.created_at, t.updated_at, t.is_deleted, t.depth,
+		       (SELECT COUNT(*) FROM ` + publishedProductCategories + ` pc WHERE pc.category_id = t.id) AS product_count,
+		       (SELECT COUNT(DISTINCT pc.product_id)
+		        FROM category_closure cc
+		        JOIN ` + publishedProductCategories + ` pc ON pc.category_id = cc.descendant_id
+		        WHERE cc.ancestor_id = t.id) AS subtree_product_count
+		FROM tree t
+		ORDER BY t.depth ASC, t.display_order ASC, t.name ASC
+	`
+
+	if err := r.db.Select(&rows, query, activeOnly, maxDepth); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// GetBreadcrumbs gets the ancestor chain of a category, from the top category down to
+// the category itself. Deleted categories have no breadcrumbs.
+func (r *categoryRepository) GetBreadcrumbs(id uuid.UUID) ([]model.Category, error) {
+	categories := []model.Category{}
+
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT ` + categoryTreeColumns + `, 0 AS depth, ARRAY[c.id] AS path
+			FROM categories c
+			WHERE c.id = $1 AND c.is_deleted = FALSE
+			UNION ALL
+			SELECT ` + categoryTreeColumns + `, a.depth + 1, a.path || c.id
+			FROM categories c
+			JOIN ancestors a ON c.id = a.parent_id
+			WHERE c.is_deleted = FALSE AND NOT c.id = ANY(a.path)
+		)
+		SELECT id, name, slug, description, parent_id, is_active, display_order, created_at, updated_at, is_deleted
+		FROM ancestors
+		ORDER BY depth DESC
+	`
+
+	if err := r.db.Select(&categories, query, id); err != nil {
+		return nil, err
 	}
 
 	return categories, nil
 }
 
+// GetProductCount counts the published products of a category
 func (r *categoryRepository) GetProductCount(categoryID uuid.UUID) (int, error) {
 	var count int
 
-	query := `SELECT COUNT(*) FROM product_categories WHERE category_id = $1`
+	query := `SELECT COUNT(*) FROM ` + publishedProductCategories + ` pc WHERE pc.category_id = $1`
+	err := r.db.Get(&count, query, categoryID)
+
+	return count, err
+}
+
+// Merge moves the products and child categories of the source category into the target,
+// points the slug of the source and its existing redirects at the target, repoints saved
+// searches and collection rules and removes the source, all in one transaction. The children keep their order
+// after the children of the target. With preview the outcome is worked out and rolled back.
+func (r *categoryRepository) Merge(sourceID, targetID uuid.UUID, preview bool) (*model.CategoryMerge, error) {
+	if sourceID == targetID {
+		return nil, ErrCategoryMergeSelf
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockCategories(tx); err != nil {
+		return nil, err
+	}
+
+	var source model.Category
+	if err := tx.Get(&source, `SELECT * FROM categories WHERE id = $1`, sourceID); err != nil {
+		return nil, err
+	}
+
+	merge := &model.CategoryMerge{
+		SourceID:        sourceID,
+		TargetID:        targetID,
+		RedirectedSlugs: []string{},
+		Preview:         preview,
+	}
+
+	var exists bool
+	if err := tx.Get(&exists, `SELECT EXISTS (SELECT 1 FROM categories WHERE id = $1 AND is_deleted = FALSE)`, targetID); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrMergeTargetNotFound
+	}
+
+	// Every child must fit below the target, which also keeps the target out of the source subtree
+	children, err := siblingIDs(tx, &sourceID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, childID := range children {
+		if err := checkCategoryParent(tx, childID, &targetID); err != nil {
+			return nil, err
+		}
+	}
+	merge.ChildrenMoved = len(children)
+
+	productsQuery := `
+		SELECT COUNT(*) FILTER (WHERE t.product_id IS NULL) AS moved,
+		       COUNT(t.product_id) AS already_in_target
+		FROM product_categories s
+		LEFT JOIN product_categories t ON t.product_id = s.product_id AND t.category_id = $2
+		WHERE s.category_id = $1
+	`
+	var products struct {
+		Moved           int `db:"moved"`
+		AlreadyInTarget int `db:"already_in_target"`
+	}
+	if err := tx.Get(&products, productsQuery, sourceID, targetID); err != nil {
+		return nil, err
+	}
+	merge.ProductsMoved = products.Moved
+	merge.ProductsAlreadyInTarget = products.AlreadyInTarget
+
+	redirectsQuery := `SELECT slug FROM category_slug_redirects WHERE category_id = $1 ORDER BY slug ASC`
+	if err := tx.Select(&merge.RedirectedSlugs, redirectsQuery, sourceID); err != nil {
+		return nil, err
+	}
+	merge.RedirectedSlugs = append([]string{source.Slug}, merge.RedirectedSlugs...)
+
+	searchesQuery := `SELECT COUNT(*) FROM saved_searches WHERE filters->>'category_id' = $1`
+	if err := tx.Get(&merge.SavedSearchesUpdated, searchesQuery, sourceID.String()); err != nil {
+		return nil, err
+	}
+
+	collectionsQuery := `SELECT COUNT(*) FROM collections WHERE rules->>'category_id' = $1`
+	if err := tx.Get(&merge.CollectionsUpdated, collectionsQuery, sourceID.String()); err != nil {
+		return nil, err
+	}
+
+	if preview {
+		return merge, nil
+	}
+
+	now := time.Now()
+
+	// Products
+	moveProducts := `
+		INSERT INTO product_categories (product_id, category_id)
+		SELECT product_id, $2 FROM product_categories WHERE category_id = $1
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(moveProducts, sourceID, targetID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`DELETE FROM product_categories WHERE category_id = $1`, sourceID); err != nil {
+		return nil, err
+	}
+
+	// Child categories, after the children of the target
+	targetChildren, err := siblingIDs(tx, &targetID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, childID := range children {
+		if _, err := tx.Exec(`UPDATE categories SET parent_id = $1, updated_at = $2 WHERE id = $3`, targetID, now, childID); err != nil {
+			return nil, err
+		}
+	}
+	if err := renumberCategories(tx, append(targetChildren, children...), now); err != nil {
+		return nil, err
+	}
+
+	// Slug redirects
+	if _, err := tx.Exec(`UPDATE category_slug_redirects SET category_id = $1 WHERE category_id = $2`, targetID, sourceID); err != nil {
+		return nil, err
+	}
+	redirect := `
+		INSERT INTO category_slug_redirects (slug, category_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (slug) DO UPDATE SET category_id = EXCLUDED.category_id, created_at = EXCLUDED.created_at
+	`
+	if _, err := tx.Exec(redirect, source.Slug, targetID, now); err != nil {
+		return nil, err
+	}
+
+	// Saved searches filtering on the source
+	updateSearches := `
+		UPDATE saved_searches SET filters = jsonb_set(filters, '{category_id}', to_jsonb($1::text)), updated_at = $3
+		WHERE filters->>'category_id' = $2
+	`
+	if _, err := tx.Exec(updateSearches, targetID.String(), sourceID.String(), now); err != nil {
+		return nil, err
+	}
+
+	// Collections selecting by the source
+	updateCollections := `
+		UPDATE collections SET rules = jsonb_set(rules, '{category_id}', to_jsonb($1::text)), updated_at = $3
+		WHERE rules->>'category_id' = $2
+	`
+	if _, err := tx.Exec(updateCollections, targetID.String(), sourceID.String(), now); err != nil {
+		return nil, err
+	}
+
+	// Remove the source and close the gap among its siblings
+	if _, err := tx.Exec(`DELETE FROM categories WHERE id = $1`, sourceID); err != nil {
+		return nil, err
+	}
+	siblings, err := siblingIDs(tx, source.ParentID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := renumberCategories(tx, siblings, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return merge, nil
+}
+
+// GetRedirect gets the category a merged category slug now points to
+func (r *categoryRepository) GetRedirect(slug string) (*model.Category, error) {
+	var categoryID uuid.UUID
+	if err := r.db.Get(&categoryID, `SELECT category_id FROM category_slug_redirects WHERE slug = $1`, slug); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(categoryID)
+}
+
+// GetPinnedProducts gets the products pinned within a category, in position order
+func (r *categoryRepository) GetPinnedProducts(categoryID uuid.UUID) ([]model.PinnedProduct, error) {
+	products := []model.PinnedProduct{}
+
+	query := `
+		SELECT pc.product_id, p.sku, p.name, pc.pin_position, pc.pinned_at
+		FROM product_categories pc
+		JOIN products p ON p.id = pc.product_id
+		WHERE pc.category_id = $1 AND pc.pin_position IS NOT NULL
+		ORDER BY pc.pin_position ASC
+	`
+	if err := r.db.Select(&products, query, categoryID); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// SetPinnedProducts pins the products within a category at their position in the list
+// and unpins the others. Every product must already be in the category. Products that
+// stay pinned keep their pinned_at. The category is touched so cached listings expire.
+func (r *categoryRepository) SetPinnedProducts(categoryID uuid.UUID, productIDs []uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	idStrings := make([]string, len(productIDs))
+	for i, productID := range productIDs {
+		idStrings[i] = productID.String()
+	}
+
+	assigned := []uuid.UUID{}
+	assignedQuery := `SELECT product_id FROM product_categories WHERE category_id = $1 AND product_id = ANY($2::uuid[])`
+	if err := tx.Select(&assigned, assignedQuery, categoryID, idStrings); err != nil {
+		return err
+	}
+	inCategory := make(map[uuid.UUID]bool, len(assigned))
+	for _, productID := range assigned {
+		inCategory[productID] = true
+	}
+	for _, productID := range productIDs {
+		if !inCategory[productID] {
+			return fmt.Errorf("%w: %s", ErrProductNotInCategory, productID)
+		}
+	}
+
+	unpin := `
+		UPDATE product_categories SET pin_position = NULL, pinned_at = NULL
+		WHERE category_id = $1 AND pin_position IS NOT NULL AND NOT (product_id = ANY($2::uuid[]))
+	`
+	if _, err := tx.Exec(unpin, categoryID, idStrings); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	pin := `
+		UPDATE product_categories SET pin_position = $1, pinned_at = COALESCE(pinned_at, $2)
+		WHERE category_id = $3 AND product_id = $4
+	`
+	for i, productID := range productIDs {
+		if _, err := tx.Exec(pin, i, now, categoryID, productID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE categories SET updated_at = $1 WHERE id = $2`, now, categoryID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetSubtreeProductCount counts the distinct published products of a category and all its descendants
+func (r *categoryRepository) GetSubtreeProductCount(categoryID uuid.UUID) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(DISTINCT pc.product_id)
+		FROM category_closure cc
+		JOIN ` + publishedProductCategories + ` pc ON pc.category_id = cc.descendant_id
+		WHERE cc.ancestor_id = $1
+	`
 	err := r.db.Get(&count, query, categoryID)
 
 	return count, err
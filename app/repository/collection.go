@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"encoding/json"
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type collectionRepository struct {
+	db       *database.DB
+	products *productRepository
+}
+
+func NewCollectionRepository(db *database.DB) CollectionRepository {
+	return &collectionRepository{
+		db:       db,
+		products: &productRepository{db: db},
+	}
+}
+
+// collectionColumns is the column list read into collectionScan
+const collectionColumns = `id, name, slug, COALESCE(description, '') AS description, rules::text AS rules,
+	sort_by, sort_order, is_active, created_at, updated_at`
+
+type collectionScan struct {
+	ID          uuid.UUID `db:"id"`
+	Name        string    `db:"name"`
+	Slug        string    `db:"slug"`
+	Description string    `db:"description"`
+	Rules       string    `db:"rules"`
+	SortBy      string    `db:"sort_by"`
+	SortOrder   string    `db:"sort_order"`
+	IsActive    bool      `db:"is_active"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (s collectionScan) toModel() (model.Collection, error) {
+	collection := model.Collection{
+		ID:          s.ID,
+		Name:        s.Name,
+		Slug:        s.Slug,
+		Description: s.Description,
+		SortBy:      s.SortBy,
+		SortOrder:   s.SortOrder,
+		IsActive:    s.IsActive,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+	err := json.Unmarshal([]byte(s.Rules), &collection.Rules)
+	return collection, err
+}
+
+func (r *collectionRepository) getCollection(query string, args ...any) (*model.Collection, error) {
+	var row collectionScan
+	if err := r.db.Get(&row, query, args...); err != nil {
+		return nil, err
+	}
+
+	collection, err := row.toModel()
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+func (r *collectionRepository) Create(collection *model.Collection) error {
+	rulesJSON, err := json.Marshal(collection.Rules)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO collections (id, name, slug, description, rules, sort_by, sort_order, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err = r.db.Exec(
+		query,
+		collection.ID,
+		collection.Name,
+		collection.Slug,
+		collection.Description,
+		rulesJSON,
+		collection.SortBy,
+		collection.SortOrder,
+		collection.IsActive,
+		collection.CreatedAt,
+		collection.UpdatedAt,
+	)
+	return err
+}
+
+func (r *collectionRepository) GetByID(id uuid.UUID) (*model.Collection, error) {
+	return r.getCollection(`SELECT `+collectionColumns+` FROM collections WHERE id = $1`, id)
+}
+
+func (r *collectionRepository) GetBySlug(slug string) (*model.Collection, error) {
+	return r.getCollection(`SELECT `+collectionColumns+` FROM collections WHERE slug = $1`, slug)
+}
+
+// SlugExists reports whether another collection has the slug
+func (r *collectionRepository) SlugExists(slug string, excludeID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM collections WHERE slug = $1 AND id <> $2)`, slug, excludeID)
+	return exists, err
+}
+
+// List lists collections ordered by name, only the active ones with activeOnly
+func (r *collectionRepository) List(offset, limit int, activeOnly bool) ([]model.Collection, int, error) {
+	var total int
+
+	where := ` WHERE ($1 = FALSE OR is_active)`
+
+	if err := r.db.Get(&total, `SELECT COUNT(*) FROM collections`+where, activeOnly); err != nil {
+		return nil, 0, err
+	}
+
+	var rows []collectionScan
+	query := `SELECT ` + collectionColumns + ` FROM collections` + where + ` ORDER BY name ASC LIMIT $2 OFFSET $3`
+	if err := r.db.Select(&rows, query, activeOnly, limit, offset); err != nil {
+		return nil, 0, err
+	}
+
+	collections := make([]model.Collection, len(rows))
+	for i, row := range rows {
+		collection, err := row.toModel()
+		if err != nil {
+			return nil, 0, err
+		}
+		collections[i] = collection
+	}
+
+	return collections, total, nil
+}
+
+func (r *collectionRepository) Update(collection *model.Collection) error {
+	rulesJSON, err := json.Marshal(collection.Rules)
+	if err != nil {
+		return err
+	}
+
+	collection.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE collections
+		SET name = $1, slug = $2, description = $3, rules = $4, sort_by = $5, sort_order = $6, is_active = $7, updated_at = $8
+		WHERE id = $9
+	`
+
+	_, err = r.db.Exec(
+		query,
+		collection.Name,
+		collection.Slug,
+		collection.Description,
+		rulesJSON,
+		collection.SortBy,
+		collection.SortOrder,
+		collection.IsActive,
+		collection.UpdatedAt,
+		collection.ID,
+	)
+	return err
+}
+
+func (r *collectionRepository) Delete(id uuid.UUID) error {
+	_, err := r.db.Exec(`DELETE FROM collections WHERE id = $1`, id)
+	return err
+}
+
+// collectionRulesClause builds the condition of the collection rules, numbering its
+// placeholders after the given args
+func collectionRulesClause(rules model.CollectionRules, args []interface{}) (string, []interface{}) {
+	if rules.IsEmpty() {
+		return "FALSE", args
+	}
+
+	conditions, args := productFilter{
+		CategoryID:         rules.CategoryID,
+		IncludeDescendants: rules.IncludeDescendants,
+		Status:             rules.Status,
+		MinPrice:           rules.MinPrice,
+		MaxPrice:           rules.MaxPrice,
+		MinStock:           rules.MinStock,
+		MaxStock:           rules.MaxStock,
+		MinRating:          rules.MinRating,
+	}.conditions(args)
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	// Attribute rules in key order, so the same rules give the same query
+	keys := make([]string, 0, len(rules.Attributes))
+	for key := range rules.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		conditions = append(conditions, "p.attributes->>"+arg(key)+" = "+arg(rules.Attributes[key]))
+	}
+
+	if len(rules.Tags) > 0 {
+		conditions = append(conditions, "p.attributes->'tags' ?| "+arg(rules.Tags)+"::text[]")
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// GetProducts gets a page of the published products of a collection. Without a
+// collection ID only the rules apply, as in a preview; with one, included products are
+// added and excluded products left out.
+func (r *collectionRepository) GetProducts(
+	collectionID *uuid.UUID,
+	rules model.CollectionRules,
+	sortBy, sortOrder string,
+	offset, limit int,
+) ([]model.Product, int, error) {
+	var total int
+
+	args := []interface{}{model.PublishStatusPublished}
+	membership, args := collectionRulesClause(rules, args)
+
+	if collectionID != nil {
+		id := strconv.Itoa(len(args) + 1)
+		args = append(args, *collectionID)
+		membership = `((` + membership + `) AND NOT EXISTS (
+				SELECT 1 FROM collection_overrides o
+				WHERE o.collection_id = $` + id + ` AND o.product_id = p.id AND o.mode = 'exclude'
+			)) OR EXISTS (
+				SELECT 1 FROM collection_overrides o
+				WHERE o.collection_id = $` + id + ` AND o.product_id = p.id AND o.mode = 'include'
+			)`
+	}
+
+	where := ` WHERE p.publish_status = $1 AND (` + membership + `)`
+
+	if err := r.db.Get(&total, `SELECT COUNT(*) FROM products p`+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	argIndex := len(args) + 1
+	query := `SELECT ` + productColumns + ` FROM products p` + where +
+		` ORDER BY p.` + sortBy + ` ` + sortOrder + `, p.id ASC` +
+		` LIMIT $` + strconv.Itoa(argIndex) + ` OFFSET $` + strconv.Itoa(argIndex+1)
+	args = append(args, limit, offset)
+
+	products, err := r.products.selectProducts(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.products.attachCategories(products); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.products.attachRatings(products); err != nil {
+		return nil, 0, err
+	}
+
+	return products, total, nil
+}
+
+// GetOverrides gets the manual overrides of a collection, ordered by product name
+func (r *collectionRepository) GetOverrides(collectionID uuid.UUID) ([]model.CollectionOverride, error) {
+	overrides := []model.CollectionOverride{}
+
+	query := `
+		SELECT o.product_id, p.sku, p.name, o.mode, o.created_at
+		FROM collection_overrides o
+		JOIN products p ON p.id = o.product_id
+		WHERE o.collection_id = $1
+		ORDER BY p.name ASC
+	`
+	if err := r.db.Select(&overrides, query, collectionID); err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+// SetOverride includes a product in a collection or excludes it, replacing an earlier override
+func (r *collectionRepository) SetOverride(collectionID, productID uuid.UUID, mode string) error {
+	query := `
+		INSERT INTO collection_overrides (collection_id, product_id, mode, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (collection_id, product_id) DO UPDATE SET mode = EXCLUDED.mode, created_at = EXCLUDED.created_at
+	`
+	_, err := r.db.Exec(query, collectionID, productID, mode, time.Now())
+	return err
+}
+
+// DeleteOverride removes the override of a product, sql.ErrNoRows when there is none
+func (r *collectionRepository) DeleteOverride(collectionID, productID uuid.UUID) error {
+	query := `DELETE FROM collection_overrides WHERE collection_id = $1 AND product_id = $2 RETURNING product_id`
+	return r.db.QueryRow(query, collectionID, productID).Scan(&productID)
+}
@@ -24,6 +24,8 @@ type UserRepository interface {
 type ProductRepository interface {
 	Create(product *model.Product, categoryIDs []uuid.UUID) error
 	GetByID(id uuid.UUID) (*model.Product, error)
+	GetByIDs(ids []uuid.UUID) ([]model.Product, error)
+	GetBySKUs(skus []string) ([]model.Product, error)
 	Update(product *model.Product, categoryIDs []uuid.UUID) error
 	Delete(id uuid.UUID) error
 	List(offset, limit int, search string, categoryID *uuid.UUID, status string) ([]model.Product, int, error)
@@ -31,13 +33,21 @@ type ProductRepository interface {
 		offset, limit int,
 		search string,
 		categoryID *uuid.UUID,
+		includeDescendants bool,
 		status string,
+		publishStatus string,
 		minPrice, maxPrice *float64,
 		minStock, maxStock *int,
+		minRating *float64,
 		createdAfter, createdBefore *time.Time,
+		publishedAfter, publishedBefore *time.Time,
+		merchandised bool,
 		sortBy, sortOrder string,
 	) ([]model.Product, int, error)
 	GetCategories(productID uuid.UUID) ([]model.Category, error)
+	GetVersion(id uuid.UUID) (*model.CatalogueVersion, error)
+	GetCatalogueVersion() (*model.CatalogueVersion, error)
+	GetPublishStatus(id uuid.UUID) (string, error)
 }
 
 type CategoryRepository interface {
@@ -48,6 +58,15 @@ type CategoryRepository interface {
 	Delete(id uuid.UUID) error
 	List() ([]model.Category, error)
 	GetProductCount(categoryID uuid.UUID) (int, error)
+	GetSubtreeProductCount(categoryID uuid.UUID) (int, error)
+	GetTree(maxDepth int, activeOnly bool) ([]model.CategoryTreeRow, error)
+	GetBreadcrumbs(id uuid.UUID) ([]model.Category, error)
+	Move(id uuid.UUID, parentID *uuid.UUID, position *int) error
+	Reorder(parentID *uuid.UUID, ids []uuid.UUID) error
+	Merge(sourceID, targetID uuid.UUID, preview bool) (*model.CategoryMerge, error)
+	GetRedirect(slug string) (*model.Category, error)
+	GetPinnedProducts(categoryID uuid.UUID) ([]model.PinnedProduct, error)
+	SetPinnedProducts(categoryID uuid.UUID, productIDs []uuid.UUID) error
 }
 type WishlistRepository interface {
 	Add(userID, productID uuid.UUID) error
@@ -59,9 +78,129 @@ type WishlistRepository interface {
 type ReviewRepository interface {
 	Create(review *model.Review) error
 	GetByID(id uuid.UUID) (*model.Review, error)
-	GetByProductID(productID uuid.UUID) ([]model.Review, error)
+	GetByProductID(productID uuid.UUID, sort string, withPhotos bool) ([]model.Review, error)
 	GetByUserID(userID uuid.UUID) ([]model.Review, error)
 	Update(review *model.Review) error
 	Delete(id uuid.UUID) error
 	List(offset, limit int) ([]model.Review, int, error)
+	GetRatingStats(productIDs []uuid.UUID) (map[uuid.UUID]model.RatingStats, error)
+	GetRatingSummary(productID uuid.UUID) (*model.RatingSummary, error)
+	Vote(reviewID, userID uuid.UUID, helpful bool) (*model.ReviewVote, error)
+	DeleteVote(reviewID, userID uuid.UUID) (*model.ReviewVote, error)
+	ListForModeration(filter model.ReviewModerationFilter, offset, limit int) ([]model.Review, int, error)
+	Moderate(ids []uuid.UUID, status, reason string, moderatedBy *uuid.UUID, moderatedAt time.Time) ([]uuid.UUID, error)
+	AddPhotos(reviewID uuid.UUID, photos []model.ReviewPhoto, maxCount int) error
+	DeletePhoto(reviewID, photoID uuid.UUID) (*model.ReviewPhoto, error)
+}
+type RecentlyViewedRepository interface {
+	Record(userID, productID uuid.UUID, viewedAt time.Time, limit int) error
+	GetByUserID(userID uuid.UUID, limit int) ([]model.RecentlyViewedItem, error)
+	Remove(userID, productID uuid.UUID) error
+	Clear(userID uuid.UUID) error
+}
+type RecommendationRepository interface {
+	Recompute(computedAt time.Time, perProduct int) error
+	GetSimilar(productID uuid.UUID, userID *uuid.UUID, limit int) ([]model.Recommendation, error)
+	GetForUser(userID uuid.UUID, limit int) ([]model.Recommendation, error)
+	CopySimilarities(fromProductID, toProductID uuid.UUID) error
+}
+type SearchRepository interface {
+	SuggestProducts(prefix string, limit int) ([]model.Suggestion, error)
+	SuggestSKUs(prefix string, limit int) ([]model.Suggestion, error)
+	SuggestCategories(prefix string, limit int) ([]model.Suggestion, error)
+	CreateSynonym(synonym *model.SearchSynonym) error
+	GetSynonym(id uuid.UUID) (*model.SearchSynonym, error)
+	ListSynonyms() ([]model.SearchSynonym, error)
+	UpdateSynonym(synonym *model.SearchSynonym) error
+	DeleteSynonym(id uuid.UUID) error
+	LogSearch(query string, resultCount int, userID *uuid.UUID) error
+	LogClick(query string, productID uuid.UUID, userID *uuid.UUID) error
+	GetQueryStats(from, to time.Time, zeroResultsOnly bool, offset, limit int) ([]model.SearchQueryStat, int, error)
+}
+type SavedSearchRepository interface {
+	Create(savedSearch *model.SavedSearch) error
+	GetByID(userID, id uuid.UUID) (*model.SavedSearch, error)
+	GetByUserID(userID uuid.UUID) ([]model.SavedSearch, error)
+	ExistsByName(userID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error)
+	Update(savedSearch *model.SavedSearch) error
+	Delete(userID, id uuid.UUID) error
+	GetNotifiable() ([]model.SavedSearch, error)
+	MarkChecked(id uuid.UUID, checkedAt time.Time) error
+}
+type PublishingRepository interface {
+	GetDraft(productID uuid.UUID) (*model.ProductDraft, error)
+	SaveDraft(draft *model.ProductDraft) error
+	SetDraftStatus(productID uuid.UUID, status string, publishAt *time.Time) error
+	PublishDraft(productID uuid.UUID, publishedAt time.Time) error
+	SetProductStatus(productID uuid.UUID, status string, publishAt, publishedAt *time.Time) error
+	ArchiveProduct(productID uuid.UUID) error
+	PublishDue(now time.Time) (int, error)
+}
+type ReportRepository interface {
+	GetProductMargins(filter model.MarginReportFilter, offset, limit int) ([]model.ProductMargin, *model.MarginSummary, error)
+	GetCategoryMargins(filter model.MarginReportFilter) ([]model.CategoryMargin, error)
+	GetStockLevels(filter model.StockReportFilter, offset, limit int) ([]model.StockLevel, *model.StockSummary, error)
+}
+type SupplierRepository interface {
+	Create(supplier *model.Supplier) error
+	GetByID(id uuid.UUID) (*model.Supplier, error)
+	List(offset, limit int, search string) ([]model.Supplier, int, error)
+	Update(supplier *model.Supplier) error
+	Delete(id uuid.UUID) error
+	GetProducts(supplierID uuid.UUID) ([]model.ProductSupplier, error)
+	GetByProductID(productID uuid.UUID) ([]model.ProductSupplier, error)
+	GetLink(productID, supplierID uuid.UUID) (*model.ProductSupplier, error)
+	SaveLink(link *model.ProductSupplier) error
+	DeleteLink(productID, supplierID uuid.UUID) error
+}
+type PurchaseOrderRepository interface {
+	Create(po *model.PurchaseOrder, lines []model.PurchaseOrderLineInput) error
+	GetByID(id uuid.UUID) (*model.PurchaseOrder, error)
+	List(offset, limit int, supplierID *uuid.UUID, status string) ([]model.PurchaseOrder, int, error)
+	Update(po *model.PurchaseOrder, lines []model.PurchaseOrderLineInput) error
+	Delete(id uuid.UUID) error
+	MarkSent(id uuid.UUID, sentAt time.Time) error
+	Receive(id uuid.UUID, receipt model.GoodsReceiptInput, receivedBy *uuid.UUID) error
+}
+type WarehouseRepository interface {
+	Create(warehouse *model.Warehouse) error
+	GetByID(id uuid.UUID) (*model.Warehouse, error)
+	GetDefault() (*model.Warehouse, error)
+	CodeExists(code string, excludeID uuid.UUID) (bool, error)
+	List() ([]model.Warehouse, error)
+	Update(warehouse *model.Warehouse) error
+	Delete(id uuid.UUID) error
+	GetStock(warehouseID uuid.UUID, offset, limit int, search string) ([]model.WarehouseStock, int, error)
+	GetProductStock(productID uuid.UUID) ([]model.WarehouseStock, error)
+	SetStock(warehouseID, productID uuid.UUID, quantity int, notes string, userID *uuid.UUID) error
+	Transfer(transfer *model.StockTransfer, notes string, userID *uuid.UUID) error
+}
+type StockCountRepository interface {
+	Create(count *model.StockCount, productIDs []uuid.UUID) error
+	GetByID(id uuid.UUID) (*model.StockCount, error)
+	List(offset, limit int, warehouseID *uuid.UUID, status string) ([]model.StockCount, int, error)
+	RecordCounts(id uuid.UUID, entries []model.StockCountEntryInput, countedBy *uuid.UUID, countedAt time.Time) error
+	Submit(id uuid.UUID, submittedAt time.Time) error
+	Cancel(id uuid.UUID) error
+	GetVariances(id uuid.UUID) ([]model.StockVariance, *model.StockVarianceSummary, error)
+	Approve(id uuid.UUID, approvedBy *uuid.UUID, approvedAt time.Time) error
+}
+type BarcodeRepository interface {
+	Create(barcode *model.ProductBarcode) error
+	GetByGTIN(gtin string) (*model.ProductBarcode, error)
+	GetByProductID(productID uuid.UUID) ([]model.ProductBarcode, error)
+	Delete(productID, id uuid.UUID) error
+}
+type CollectionRepository interface {
+	Create(collection *model.Collection) error
+	GetByID(id uuid.UUID) (*model.Collection, error)
+	GetBySlug(slug string) (*model.Collection, error)
+	SlugExists(slug string, excludeID uuid.UUID) (bool, error)
+	List(offset, limit int, activeOnly bool) ([]model.Collection, int, error)
+	Update(collection *model.Collection) error
+	Delete(id uuid.UUID) error
+	GetProducts(collectionID *uuid.UUID, rules model.CollectionRules, sortBy, sortOrder string, offset, limit int) ([]model.Product, int, error)
+	GetOverrides(collectionID uuid.UUID) ([]model.CollectionOverride, error)
+	SetOverride(collectionID, productID uuid.UUID, mode string) error
+	DeleteOverride(collectionID, productID uuid.UUID) error
 }
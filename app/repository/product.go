@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 )
 
+var ErrProductHasPurchaseOrders = NewError("product is on purchase orders")
+
 type productRepository struct {
 	db *database.DB
 }
@@ -31,8 +34,9 @@ func (p productRepository) Create(product *model.Product, categoryIDs []uuid.UUI
 	// Insert product
 	query := `
 		INSERT INTO products (id, sku, name, description, price, sale_price, cost_price, 
-		                     stock_quantity, status, attributes, created_at, updated_at, is_deleted)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		                     stock_quantity, status, attributes, created_at, updated_at, is_deleted,
+		                     publish_status, publish_at, published_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
 
 	_, err = tx.Exec(
@@ -50,12 +54,20 @@ func (p productRepository) Create(product *model.Product, categoryIDs []uuid.UUI
 		product.CreatedAt,
 		product.UpdatedAt,
 		false,
+		product.PublishStatus,
+		product.PublishAt,
+		product.PublishedAt,
 	)
 
 	if err != nil {
 		return err
 	}
 
+	// The initial stock is held in the default warehouse
+	if err := setProductStock(tx, product.ID, product.StockQuantity, product.UpdatedAt); err != nil {
+		return err
+	}
+
 	// Insert product categories
 	for _, categoryID := range categoryIDs {
 		_, err = tx.Exec(
@@ -71,74 +83,25 @@ func (p productRepository) Create(product *model.Product, categoryIDs []uuid.UUI
 	return tx.Commit()
 }
 func (r *productRepository) GetByID(id uuid.UUID) (*model.Product, error) {
-	// Define a temporary struct with nullable fields
-	type productScan struct {
-		ID            uuid.UUID       `db:"id"`
-		SKU           string          `db:"sku"`
-		Name          string          `db:"name"`
-		Description   sql.NullString  `db:"description"`
-		Price         float64         `db:"price"`
-		SalePrice     sql.NullFloat64 `db:"sale_price"`
-		CostPrice     sql.NullFloat64 `db:"cost_price"`
-		StockQuantity int             `db:"stock_quantity"`
-		Status        string          `db:"status"`
-		Attributes    string          `db:"attributes"`
-		CreatedAt     time.Time       `db:"created_at"`
-		UpdatedAt     time.Time       `db:"updated_at"`
-	}
-
-	var scanProduct productScan
+	query := `SELECT ` + productColumns + ` FROM products p WHERE p.id = $1`
 
-	query := `
-        SELECT p.id, p.sku, p.name, p.description, p.price, p.sale_price, p.cost_price, 
-               p.stock_quantity, p.status, to_json(p.attributes) as attributes, 
-               p.created_at, p.updated_at
-        FROM products p
-        WHERE p.id = $1
-    `
-
-	err := r.db.Get(&scanProduct, query, id)
+	products, err := r.selectProducts(query, id)
 	if err != nil {
 		return nil, err
 	}
-
-	// Convert to model.Product
-	product := model.Product{
-		ID:            scanProduct.ID,
-		SKU:           scanProduct.SKU,
-		Name:          scanProduct.Name,
-		Description:   scanProduct.Description.String,
-		Price:         scanProduct.Price,
-		StockQuantity: scanProduct.StockQuantity,
-		Status:        scanProduct.Status,
-		CreatedAt:     scanProduct.CreatedAt,
-		UpdatedAt:     scanProduct.UpdatedAt,
-	}
-
-	// Handle nullable fields
-	if scanProduct.SalePrice.Valid {
-		product.SalePrice = scanProduct.SalePrice.Float64
+	if len(products) == 0 {
+		return nil, sql.ErrNoRows
 	}
 
-	if scanProduct.CostPrice.Valid {
-		product.CostPrice = scanProduct.CostPrice.Float64
-	}
-
-	// Parse attributes JSON
-	if scanProduct.Attributes != "" {
-		if err := json.Unmarshal([]byte(scanProduct.Attributes), &product.Attributes); err != nil {
-			return nil, err
-		}
+	// Get categories and rating summary
+	if err := r.attachCategories(products); err != nil {
+		return nil, err
 	}
-
-	// Get categories
-	categories, err := r.GetCategories(id)
-	if err != nil {
+	if err := r.attachRatings(products); err != nil {
 		return nil, err
 	}
-	product.Categories = categories
 
-	return &product, nil
+	return &products[0], nil
 }
 
 func (r *productRepository) Update(product *model.Product, categoryIDs []uuid.UUID) error {
@@ -148,6 +111,16 @@ func (r *productRepository) Update(product *model.Product, categoryIDs []uuid.UU
 	}
 	defer tx.Rollback()
 
+	if err := updateProduct(tx, product, categoryIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// updateProduct updates the product fields and categories within the transaction.
+// The publish status is left as is, see PublishingRepository.
+func updateProduct(tx *sqlx.Tx, product *model.Product, categoryIDs []uuid.UUID) error {
 	// Convert attributes to JSON
 	attributesJSON, err := json.Marshal(product.Attributes)
 	if err != nil {
@@ -160,8 +133,8 @@ func (r *productRepository) Update(product *model.Product, categoryIDs []uuid.UU
 	query := `
 		UPDATE products
 		SET sku = $1, name = $2, description = $3, price = $4, sale_price = $5, 
-		    cost_price = $6, stock_quantity = $7, status = $8, attributes = $9, updated_at = $10
-		WHERE id = $11
+		    cost_price = $6, status = $7, attributes = $8, updated_at = $9
+		WHERE id = $10
 	`
 
 	_, err = tx.Exec(
@@ -172,7 +145,6 @@ func (r *productRepository) Update(product *model.Product, categoryIDs []uuid.UU
 		product.Price,
 		product.SalePrice,
 		product.CostPrice,
-		product.StockQuantity,
 		product.Status,
 		attributesJSON,
 		product.UpdatedAt,
@@ -183,8 +155,17 @@ func (r *productRepository) Update(product *model.Product, categoryIDs []uuid.UU
 		return err
 	}
 
-	// Delete existing product categories
-	_, err = tx.Exec("DELETE FROM product_categories WHERE product_id = $1", product.ID)
+	// Stock is held per warehouse, the change goes to the default warehouse
+	if err := setProductStock(tx, product.ID, product.StockQuantity, product.UpdatedAt); err != nil {
+		return err
+	}
+
+	// Delete the product categories the product left, keeping the merchandising of the others
+	idStrings := make([]string, len(categoryIDs))
+	for i, categoryID := range categoryIDs {
+		idStrings[i] = categoryID.String()
+	}
+	_, err = tx.Exec("DELETE FROM product_categories WHERE product_id = $1 AND NOT (category_id = ANY($2::uuid[]))", product.ID, idStrings)
 	if err != nil {
 		return err
 	}
@@ -192,7 +173,7 @@ func (r *productRepository) Update(product *model.Product, categoryIDs []uuid.UU
 	// Insert new product categories
 	for _, categoryID := range categoryIDs {
 		_, err = tx.Exec(
-			"INSERT INTO product_categories (product_id, category_id) VALUES ($1, $2)",
+			"INSERT INTO product_categories (product_id, category_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
 			product.ID,
 			categoryID,
 		)
@@ -201,42 +182,28 @@ func (r *productRepository) Update(product *model.Product, categoryIDs []uuid.UU
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 func (r *productRepository) Delete(id uuid.UUID) error {
+	// Purchase order lines keep the product for the order history
+	var ordered bool
+	if err := r.db.Get(&ordered, "SELECT EXISTS (SELECT 1 FROM purchase_order_lines WHERE product_id = $1)", id); err != nil {
+		return err
+	}
+	if ordered {
+		return ErrProductHasPurchaseOrders
+	}
+
 	return r.db.QueryRow("DELETE FROM products WHERE id = $1 RETURNING id", id).Scan(&id)
 }
 
 func (r *productRepository) List(offset, limit int, search string, categoryID *uuid.UUID, status string) ([]model.Product, int, error) {
 	var total int
 
-	// Define a scan struct with nullable fields
-	type productScan struct {
-		ID            uuid.UUID       `db:"id"`
-		SKU           string          `db:"sku"`
-		Name          string          `db:"name"`
-		Description   sql.NullString  `db:"description"`
-		Price         float64         `db:"price"`
-		SalePrice     sql.NullFloat64 `db:"sale_price"`
-		CostPrice     sql.NullFloat64 `db:"cost_price"`
-		StockQuantity int             `db:"stock_quantity"`
-		Status        string          `db:"status"`
-		Attributes    string          `db:"attributes"`
-		CreatedAt     time.Time       `db:"created_at"`
-		UpdatedAt     time.Time       `db:"updated_at"`
-	}
-
-	var scanProducts []productScan
-
 	// Base queries
 	countQuery := `SELECT COUNT(*) FROM products p`
-	listQuery := `
-		SELECT p.id, p.sku, p.name, p.description, p.price, p.sale_price, p.cost_price, 
-		       p.stock_quantity, p.status, to_json(p.attributes) as attributes, 
-		       p.created_at, p.updated_at
-		FROM products p
-	`
+	listQuery := `SELECT ` + productColumns + ` FROM products p`
 
 	// Build WHERE clause
 	whereClause := ""
@@ -283,53 +250,97 @@ func (r *productRepository) List(offset, limit int, search string, categoryID *u
 	}
 
 	// Get products
-	err = r.db.Select(&scanProducts, listQuery, args...)
+	products, err := r.selectProducts(listQuery, args...)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// Convert scanProducts to model.Product
-	products := make([]model.Product, len(scanProducts))
-	for i, scanProduct := range scanProducts {
-		products[i] = model.Product{
-			ID:            scanProduct.ID,
-			SKU:           scanProduct.SKU,
-			Name:          scanProduct.Name,
-			Description:   scanProduct.Description.String,
-			Price:         scanProduct.Price,
-			StockQuantity: scanProduct.StockQuantity,
-			Status:        scanProduct.Status,
-			CreatedAt:     scanProduct.CreatedAt,
-			UpdatedAt:     scanProduct.UpdatedAt,
-		}
+	// Get categories for all products
+	if err := r.attachCategories(products); err != nil {
+		return nil, 0, err
+	}
 
-		// Handle nullable fields
-		if scanProduct.SalePrice.Valid {
-			products[i].SalePrice = scanProduct.SalePrice.Float64
-		}
+	return products, total, nil
+}
 
-		if scanProduct.CostPrice.Valid {
-			products[i].CostPrice = scanProduct.CostPrice.Float64
-		}
+// productFilter holds the catalogue filters shared by the product list and the rules
+// of collections
+type productFilter struct {
+	CategoryID         *uuid.UUID
+	IncludeDescendants bool
+	Status             string
+	PublishStatus      string
+	MinPrice, MaxPrice *float64
+	MinStock, MaxStock *int
+	MinRating          *float64
+	CreatedAfter       *time.Time
+	CreatedBefore      *time.Time
+	PublishedAfter     *time.Time
+	PublishedBefore    *time.Time
+}
 
-		// Parse attributes JSON
-		if scanProduct.Attributes != "" {
-			if err := json.Unmarshal([]byte(scanProduct.Attributes), &products[i].Attributes); err != nil {
-				return nil, 0, err
-			}
-		}
+// conditions builds the conditions of the filters that are set, on products aliased p,
+// numbering their placeholders after the given args
+func (f productFilter) conditions(args []interface{}) ([]string, []interface{}) {
+	conditions := []string{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
 	}
 
-	// Get categories for each product
-	for i := range products {
-		categories, err := r.GetCategories(products[i].ID)
-		if err != nil {
-			return nil, 0, err
-		}
-		products[i].Categories = categories
+	// Category, with the descendants of the category when asked
+	if f.CategoryID != nil && f.IncludeDescendants {
+		conditions = append(conditions, `p.id IN (
+			SELECT pc.product_id FROM product_categories pc
+			JOIN category_closure cc ON cc.descendant_id = pc.category_id
+			WHERE cc.ancestor_id = `+arg(*f.CategoryID)+`)`)
+	} else if f.CategoryID != nil {
+		conditions = append(conditions, "p.id IN (SELECT product_id FROM product_categories WHERE category_id = "+arg(*f.CategoryID)+")")
 	}
 
-	return products, total, nil
+	if f.Status != "" {
+		conditions = append(conditions, "p.status = "+arg(f.Status))
+	}
+	if f.PublishStatus != "" {
+		conditions = append(conditions, "p.publish_status = "+arg(f.PublishStatus))
+	}
+
+	// Price and stock ranges
+	if f.MinPrice != nil {
+		conditions = append(conditions, "p.price >= "+arg(*f.MinPrice))
+	}
+	if f.MaxPrice != nil {
+		conditions = append(conditions, "p.price <= "+arg(*f.MaxPrice))
+	}
+	if f.MinStock != nil {
+		conditions = append(conditions, "p.stock_quantity >= "+arg(*f.MinStock))
+	}
+	if f.MaxStock != nil {
+		conditions = append(conditions, "p.stock_quantity <= "+arg(*f.MaxStock))
+	}
+
+	// Rating, products without approved reviews have no rating
+	if f.MinRating != nil {
+		conditions = append(conditions, `p.id IN (
+			SELECT product_id FROM product_rating_summaries
+			WHERE review_count > 0 AND average_rating >= `+arg(*f.MinRating)+`)`)
+	}
+
+	// Date ranges
+	if f.CreatedAfter != nil {
+		conditions = append(conditions, "p.created_at >= "+arg(*f.CreatedAfter))
+	}
+	if f.CreatedBefore != nil {
+		conditions = append(conditions, "p.created_at <= "+arg(*f.CreatedBefore))
+	}
+	if f.PublishedAfter != nil {
+		conditions = append(conditions, "p.published_at >= "+arg(*f.PublishedAfter))
+	}
+	if f.PublishedBefore != nil {
+		conditions = append(conditions, "p.published_at <= "+arg(*f.PublishedBefore))
+	}
+
+	return conditions, args
 }
 
 // ListWithFilters gets a list of products with comprehensive filtering options
@@ -337,39 +348,23 @@ func (r *productRepository) ListWithFilters(
 	offset, limit int,
 	search string,
 	categoryID *uuid.UUID,
+	includeDescendants bool,
 	status string,
+	publishStatus string,
 	minPrice, maxPrice *float64,
 	minStock, maxStock *int,
+	minRating *float64,
 	createdAfter, createdBefore *time.Time,
+	publishedAfter, publishedBefore *time.Time,
+	merchandised bool,
 	sortBy, sortOrder string,
 ) ([]model.Product, int, error) {
 	var total int
 
-	// Define a scan struct with nullable fields
-	type productScan struct {
-		ID            uuid.UUID       `db:"id"`
-		SKU           string          `db:"sku"`
-		Name          string          `db:"name"`
-		Description   sql.NullString  `db:"description"`
-		Price         float64         `db:"price"`
-		SalePrice     sql.NullFloat64 `db:"sale_price"`
-		CostPrice     sql.NullFloat64 `db:"cost_price"`
-		StockQuantity int             `db:"stock_quantity"`
-		Status        string          `db:"status"`
-		Attributes    string          `db:"attributes"`
-		CreatedAt     time.Time       `db:"created_at"`
-		UpdatedAt     time.Time       `db:"updated_at"`
-	}
-
-	var scanProducts []productScan
-
-	// Base queries
+	// Base queries, the list joining the rating summary for the rating sort
 	countQuery := `SELECT COUNT(*) FROM products p`
-	listQuery := `
-		SELECT p.id, p.sku, p.name, p.description, p.price, p.sale_price, p.cost_price, 
-		       p.stock_quantity, p.status, to_json(p.attributes) as attributes, 
-		       p.created_at, p.updated_at
-		FROM products p
+	listQuery := `SELECT ` + productColumns + ` FROM products p
+		LEFT JOIN product_rating_summaries rs ON rs.product_id = p.id
 	`
 
 	// Build WHERE clause
@@ -377,84 +372,142 @@ func (r *productRepository) ListWithFilters(
 	args := []interface{}{}
 	argIndex := 1
 
-	// Add search filter
+	// Add search filter: the search term or any of its synonyms matches as a substring,
+	// or approximately (pg_trgm word similarity) to tolerate typos in product names
 	if search != "" {
-		whereClause += " AND (p.name ILIKE $" + strconv.Itoa(argIndex) + " OR p.sku ILIKE $" + strconv.Itoa(argIndex) + " OR p.description ILIKE $" + strconv.Itoa(argIndex) + ")"
-		args = append(args, "%"+search+"%")
+		whereClause += ` AND EXISTS (
+			SELECT 1 FROM (
+				SELECT lower(trim($` + strconv.Itoa(argIndex) + `::text)) AS term
+				UNION
+				SELECT unnest(s.terms) FROM search_synonyms s WHERE lower(trim($` + strconv.Itoa(argIndex) + `::text)) = ANY(s.terms)
+			) t
+			WHERE p.name ILIKE '%' || t.term || '%' OR p.sku ILIKE '%' || t.term || '%'
+			   OR p.description ILIKE '%' || t.term || '%' OR t.term <% lower(p.name)
+		)`
+		args = append(args, search)
 		argIndex++
 	}
 
-	// Add category filter
-	if categoryID != nil {
-		whereClause += " AND p.id IN (SELECT product_id FROM product_categories WHERE category_id = $" + strconv.Itoa(argIndex) + ")"
+	// Add the catalogue filters
+	conditions, args := productFilter{
+		CategoryID:         categoryID,
+		IncludeDescendants: includeDescendants,
+		Status:             status,
+		PublishStatus:      publishStatus,
+		MinPrice:           minPrice,
+		MaxPrice:           maxPrice,
+		MinStock:           minStock,
+		MaxStock:           maxStock,
+		MinRating:          minRating,
+		CreatedAfter:       createdAfter,
+		CreatedBefore:      createdBefore,
+		PublishedAfter:     publishedAfter,
+		PublishedBefore:    publishedBefore,
+	}.conditions(args)
+	for _, condition := range conditions {
+		whereClause += " AND " + condition
+	}
+	argIndex = len(args) + 1
+	countArgs := args
+
+	// Add sort order, pinned products of the category first when merchandised. By rating,
+	// ties are broken by review count and products without reviews rate 0.
+	sortClause := "p." + sortBy + " " + sortOrder
+	if sortBy == "rating" {
+		sortClause = "COALESCE(rs.average_rating, 0) " + sortOrder + ", COALESCE(rs.review_count, 0) " + sortOrder
+	}
+	orderByClause := " ORDER BY " + sortClause
+	if merchandised && categoryID != nil {
+		orderByClause = ` ORDER BY (
+			SELECT pc.pin_position FROM product_categories pc
+			WHERE pc.product_id = p.id AND pc.category_id = $` + strconv.Itoa(argIndex) + `
+		) ASC NULLS LAST, ` + sortClause
 		args = append(args, *categoryID)
 		argIndex++
 	}
 
-	// Add status filter
-	if status != "" {
-		whereClause += " AND p.status = $" + strconv.Itoa(argIndex)
-		args = append(args, status)
-		argIndex++
-	}
-
-	// Add price range filters
-	if minPrice != nil {
-		whereClause += " AND p.price >= $" + strconv.Itoa(argIndex)
-		args = append(args, *minPrice)
-		argIndex++
-	}
-	if maxPrice != nil {
-		whereClause += " AND p.price <= $" + strconv.Itoa(argIndex)
-		args = append(args, *maxPrice)
-		argIndex++
-	}
-
-	// Add stock quantity filters
-	if minStock != nil {
-		whereClause += " AND p.stock_quantity >= $" + strconv.Itoa(argIndex)
-		args = append(args, *minStock)
-		argIndex++
-	}
-	if maxStock != nil {
-		whereClause += " AND p.stock_quantity <= $" + strconv.Itoa(argIndex)
-		args = append(args, *maxStock)
-		argIndex++
-	}
-
-	// Add date range filters
-	if createdAfter != nil {
-		whereClause += " AND p.created_at >= $" + strconv.Itoa(argIndex)
-		args = append(args, *createdAfter)
-		argIndex++
-	}
-	if createdBefore != nil {
-		whereClause += " AND p.created_at <= $" + strconv.Itoa(argIndex)
-		args = append(args, *createdBefore)
-		argIndex++
-	}
-
-	// Add sort order
-	orderByClause := " ORDER BY p." + sortBy + " " + sortOrder
-
 	// Complete queries
 	countQuery += whereClause
 	listQuery += whereClause + orderByClause + " LIMIT $" + strconv.Itoa(argIndex) + " OFFSET $" + strconv.Itoa(argIndex+1)
 	args = append(args, limit, offset)
 
 	// Get total count
-	err := r.db.Get(&total, countQuery, args[:argIndex-1]...)
+	err := r.db.Get(&total, countQuery, countArgs...)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get products
-	err = r.db.Select(&scanProducts, listQuery, args...)
+	products, err := r.selectProducts(listQuery, args...)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// Convert scanProducts to model.Product
+	// Get categories for all products
+	if err := r.attachCategories(products); err != nil {
+		return nil, 0, err
+	}
+
+	// Get rating summaries
+	if err := r.attachRatings(products); err != nil {
+		return nil, 0, err
+	}
+
+	return products, total, nil
+}
+
+func (r *productRepository) GetCategories(productID uuid.UUID) ([]model.Category, error) {
+	var categories []model.Category
+
+	query := `
+		SELECT c.*
+		FROM categories c
+		JOIN product_categories pc ON c.id = pc.category_id
+		WHERE pc.product_id = $1
+	`
+
+	err := r.db.Select(&categories, query, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// productScan is a product row read with productColumns, with nullable fields
+type productScan struct {
+	ID            uuid.UUID       `db:"id"`
+	SKU           string          `db:"sku"`
+	Name          string          `db:"name"`
+	Description   sql.NullString  `db:"description"`
+	Price         float64         `db:"price"`
+	SalePrice     sql.NullFloat64 `db:"sale_price"`
+	CostPrice     sql.NullFloat64 `db:"cost_price"`
+	StockQuantity int             `db:"stock_quantity"`
+	Status        string          `db:"status"`
+	PublishStatus string          `db:"publish_status"`
+	PublishAt     sql.NullTime    `db:"publish_at"`
+	PublishedAt   sql.NullTime    `db:"published_at"`
+	Attributes    string          `db:"attributes"`
+	CreatedAt     time.Time       `db:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at"`
+}
+
+// productColumns is the column list read by selectProducts
+const productColumns = `
+	p.id, p.sku, p.name, p.description, p.price, p.sale_price, p.cost_price,
+	p.stock_quantity, p.status, p.publish_status, p.publish_at, p.published_at, to_json(p.attributes) as attributes,
+	p.created_at, p.updated_at
+`
+
+// selectProducts runs a query returning productColumns and converts the rows to
+// model.Product. Categories are not loaded, see attachCategories.
+func (r *productRepository) selectProducts(query string, args ...interface{}) ([]model.Product, error) {
+	var scanProducts []productScan
+	if err := r.db.Select(&scanProducts, query, args...); err != nil {
+		return nil, err
+	}
+
 	products := make([]model.Product, len(scanProducts))
 	for i, scanProduct := range scanProducts {
 		products[i] = model.Product{
@@ -465,6 +518,9 @@ func (r *productRepository) ListWithFilters(
 			Price:         scanProduct.Price,
 			StockQuantity: scanProduct.StockQuantity,
 			Status:        scanProduct.Status,
+			PublishStatus: scanProduct.PublishStatus,
+			PublishAt:     nullTimePtr(scanProduct.PublishAt),
+			PublishedAt:   nullTimePtr(scanProduct.PublishedAt),
 			CreatedAt:     scanProduct.CreatedAt,
 			UpdatedAt:     scanProduct.UpdatedAt,
 		}
@@ -481,39 +537,187 @@ func (r *productRepository) ListWithFilters(
 		// Parse attributes JSON
 		if scanProduct.Attributes != "" {
 			if err := json.Unmarshal([]byte(scanProduct.Attributes), &products[i].Attributes); err != nil {
-				return nil, 0, err
+				return nil, err
 			}
 		}
 	}
 
-	// Get categories for each product
+	return products, nil
+}
+
+// attachCategories loads the categories of all given products with a single query
+func (r *productRepository) attachCategories(products []model.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(products))
 	for i := range products {
-		categories, err := r.GetCategories(products[i].ID)
-		if err != nil {
-			return nil, 0, err
-		}
-		products[i].Categories = categories
+		ids[i] = products[i].ID.String()
 	}
 
-	return products, total, nil
-}
+	type categoryScan struct {
+		ProductID uuid.UUID `db:"product_id"`
+		model.Category
+	}
 
-func (r *productRepository) GetCategories(productID uuid.UUID) ([]model.Category, error) {
-	var categories []model.Category
+	var rows []categoryScan
 
 	query := `
-		SELECT c.*
+		SELECT pc.product_id, c.*
 		FROM categories c
 		JOIN product_categories pc ON c.id = pc.category_id
-		WHERE pc.product_id = $1
+		WHERE pc.product_id = ANY($1::uuid[])
 	`
 
-	err := r.db.Select(&categories, query, productID)
+	if err := r.db.Select(&rows, query, ids); err != nil {
+		return err
+	}
+
+	byProduct := make(map[uuid.UUID][]model.Category, len(products))
+	for _, row := range rows {
+		byProduct[row.ProductID] = append(byProduct[row.ProductID], row.Category)
+	}
+
+	for i := range products {
+		products[i].Categories = byProduct[products[i].ID]
+	}
+
+	return nil
+}
+
+// attachRatings loads the rating summaries of all given products with a single query
+func (r *productRepository) attachRatings(products []model.Product) error {
+	ids := make([]uuid.UUID, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+	}
+
+	summaries, err := getRatingSummaries(r.db, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range products {
+		summary := summaries[products[i].ID]
+		products[i].Rating = &summary
+	}
+
+	return nil
+}
+
+// GetByIDs gets the products with the given IDs, with their categories and ratings, in no particular order
+func (r *productRepository) GetByIDs(ids []uuid.UUID) ([]model.Product, error) {
+	if len(ids) == 0 {
+		return []model.Product{}, nil
+	}
+
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = id.String()
+	}
+
+	query := `SELECT ` + productColumns + ` FROM products p WHERE p.id = ANY($1::uuid[])`
+	products, err := r.selectProducts(query, idStrings)
 	if err != nil {
 		return nil, err
 	}
 
-	return categories, nil
+	if err := r.attachCategories(products); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachRatings(products); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetBySKUs gets the products with the given SKUs, with their categories and ratings, in no particular order
+func (r *productRepository) GetBySKUs(skus []string) ([]model.Product, error) {
+	if len(skus) == 0 {
+		return []model.Product{}, nil
+	}
+
+	query := `SELECT ` + productColumns + ` FROM products p WHERE p.sku = ANY($1::text[])`
+	products, err := r.selectProducts(query, skus)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.attachCategories(products); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachRatings(products); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetVersion returns the cache validator data of a product, taking its categories and rating into account
+func (r *productRepository) GetVersion(id uuid.UUID) (*model.CatalogueVersion, error) {
+	var version model.CatalogueVersion
+
+	query := `
+		SELECT GREATEST(p.updated_at, MAX(c.updated_at),
+		           (SELECT rs.updated_at FROM product_rating_summaries rs WHERE rs.product_id = p.id)
+		       ) AS last_modified, COUNT(c.id) AS count
+		FROM products p
+		LEFT JOIN product_categories pc ON pc.product_id = p.id
+		LEFT JOIN categories c ON c.id = pc.category_id
+		WHERE p.id = $1
+		GROUP BY p.id
+	`
+
+	err := r.db.Get(&version, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// GetCatalogueVersion returns the cache validator data of the whole catalogue.
+// The count changes on deletions and category reassignments, which the maximum
+// updated_at alone does not reflect.
+func (r *productRepository) GetCatalogueVersion() (*model.CatalogueVersion, error) {
+	var version model.CatalogueVersion
+
+	query := `
+		SELECT COALESCE(GREATEST(
+		           (SELECT MAX(updated_at) FROM products),
+		           (SELECT MAX(updated_at) FROM categories),
+		           (SELECT MAX(updated_at) FROM product_rating_summaries)
+		       ), 'epoch'::timestamp) AS last_modified,
+		       (SELECT COUNT(*) FROM products) +
+		       (SELECT COUNT(*) FROM categories) +
+		       (SELECT COUNT(*) FROM product_categories) AS count
+	`
+
+	err := r.db.Get(&version, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// GetPublishStatus returns the publish status of a product
+func (r *productRepository) GetPublishStatus(id uuid.UUID) (string, error) {
+	var publishStatus string
+	err := r.db.Get(&publishStatus, `SELECT publish_status FROM products WHERE id = $1`, id)
+	return publishStatus, err
+}
+
+// nullTimePtr converts a nullable timestamp to a pointer, nil for NULL
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
 }
 
 func NewProductRepository(db *database.DB) ProductRepository {
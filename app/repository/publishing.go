@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type publishingRepository struct {
+	db *database.DB
+}
+
+func NewPublishingRepository(db *database.DB) PublishingRepository {
+	return &publishingRepository{
+		db: db,
+	}
+}
+
+type productDraftScan struct {
+	ProductID uuid.UUID    `db:"product_id"`
+	Data      string       `db:"data"`
+	Status    string       `db:"status"`
+	PublishAt sql.NullTime `db:"publish_at"`
+	UpdatedBy *uuid.UUID   `db:"updated_by"`
+	CreatedAt time.Time    `db:"created_at"`
+	UpdatedAt time.Time    `db:"updated_at"`
+}
+
+func (s productDraftScan) toModel() (*model.ProductDraft, error) {
+	draft := &model.ProductDraft{
+		ProductID: s.ProductID,
+		Status:    s.Status,
+		PublishAt: nullTimePtr(s.PublishAt),
+		UpdatedBy: s.UpdatedBy,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(s.Data), &draft.Data); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// getDraft reads the pending draft of a product, from the database or within a transaction
+func getDraft(q sqlx.Queryer, productID uuid.UUID) (*model.ProductDraft, error) {
+	var row productDraftScan
+
+	query := `
+		SELECT product_id, data::text AS data, status, publish_at, updated_by, created_at, updated_at
+		FROM product_drafts
+		WHERE product_id = $1
+	`
+	if err := sqlx.Get(q, &row, query, productID); err != nil {
+		return nil, err
+	}
+
+	return row.toModel()
+}
+
+// GetDraft returns the pending draft of a product, nil when it has none
+func (r *publishingRepository) GetDraft(productID uuid.UUID) (*model.ProductDraft, error) {
+	draft, err := getDraft(r.db, productID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return draft, err
+}
+
+// SaveDraft creates or replaces the pending draft of a product
+func (r *publishingRepository) SaveDraft(draft *model.ProductDraft) error {
+	dataJSON, err := json.Marshal(draft.Data)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO product_drafts (product_id, data, status, publish_at, updated_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (product_id) DO UPDATE
+		SET data = EXCLUDED.data, status = EXCLUDED.status, publish_at = EXCLUDED.publish_at,
+		    updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err = r.db.Exec(
+		query,
+		draft.ProductID,
+		dataJSON,
+		draft.Status,
+		draft.PublishAt,
+		draft.UpdatedBy,
+		draft.CreatedAt,
+		draft.UpdatedAt,
+	)
+	return err
+}
+
+func (r *publishingRepository) SetDraftStatus(productID uuid.UUID, status string, publishAt *time.Time) error {
+	query := `UPDATE product_drafts SET status = $1, publish_at = $2 WHERE product_id = $3`
+	_, err := r.db.Exec(query, status, publishAt, productID)
+	return err
+}
+
+// applyDraft copies the pending draft onto the product row and removes the draft
+func applyDraft(tx *sqlx.Tx, productID uuid.UUID) error {
+	draft, err := getDraft(tx, productID)
+	if err != nil {
+		return err
+	}
+
+	// Stock keeps moving while the draft waits, through goods receipts and warehouse
+	// adjustments, so the live stock quantity is kept
+	var stockQuantity int
+	if err := tx.Get(&stockQuantity, `SELECT stock_quantity FROM products WHERE id = $1`, productID); err != nil {
+		return err
+	}
+
+	input := draft.Data
+	product := &model.Product{
+		ID:            productID,
+		SKU:           input.SKU,
+		Name:          input.Name,
+		Description:   input.Description,
+		Price:         input.Price,
+		StockQuantity: stockQuantity,
+		Status:        input.Status,
+		Attributes:    input.Attributes,
+	}
+	if input.SalePrice != nil {
+		product.SalePrice = *input.SalePrice
+	}
+	if input.CostPrice != nil {
+		product.CostPrice = *input.CostPrice
+	}
+
+	if err := updateProduct(tx, product, input.CategoryIDs); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM product_drafts WHERE product_id = $1`, productID)
+	return err
+}
+
+// PublishDraft makes the pending draft of a published product the live version
+func (r *publishingRepository) PublishDraft(productID uuid.UUID, publishedAt time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := applyDraft(tx, productID); err != nil {
+		return err
+	}
+
+	query := `UPDATE products SET published_at = $1 WHERE id = $2`
+	if _, err := tx.Exec(query, publishedAt, productID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetProductStatus moves a product that has no live version in the workflow
+func (r *publishingRepository) SetProductStatus(productID uuid.UUID, status string, publishAt, publishedAt *time.Time) error {
+	query := `
+		UPDATE products
+		SET publish_status = $1, publish_at = $2, published_at = $3, updated_at = $4
+		WHERE id = $5
+	`
+	_, err := r.db.Exec(query, status, publishAt, publishedAt, time.Now(), productID)
+	return err
+}
+
+// ArchiveProduct takes a published product offline. A pending draft is folded into
+// the product, which is edited directly from then on since it is no longer live.
+func (r *publishingRepository) ArchiveProduct(productID uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var hasDraft bool
+	if err := tx.Get(&hasDraft, `SELECT EXISTS(SELECT 1 FROM product_drafts WHERE product_id = $1)`, productID); err != nil {
+		return err
+	}
+	if hasDraft {
+		if err := applyDraft(tx, productID); err != nil {
+			return err
+		}
+	}
+
+	query := `UPDATE products SET publish_status = $1, publish_at = NULL, updated_at = $2 WHERE id = $3`
+	if _, err := tx.Exec(query, model.PublishStatusArchived, time.Now(), productID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// PublishDue publishes the scheduled products and drafts whose publish time has come,
+// and returns how many were published
+func (r *publishingRepository) PublishDue(now time.Time) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	productsQuery := `
+		UPDATE products
+		SET publish_status = $1, published_at = $2, publish_at = NULL, updated_at = $2
+		WHERE publish_status = $3 AND publish_at <= $2
+	`
+	result, err := tx.Exec(productsQuery, model.PublishStatusPublished, now, model.PublishStatusScheduled)
+	if err != nil {
+		return 0, err
+	}
+	published, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	var draftProductIDs []uuid.UUID
+	draftsQuery := `
+		SELECT product_id FROM product_drafts
+		WHERE status = $1 AND publish_at <= $2
+		FOR UPDATE SKIP LOCKED
+	`
+	if err := tx.Select(&draftProductIDs, draftsQuery, model.PublishStatusScheduled, now); err != nil {
+		return 0, err
+	}
+
+	for _, productID := range draftProductIDs {
+		if err := applyDraft(tx, productID); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(`UPDATE products SET published_at = $1 WHERE id = $2`, now, productID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(published) + len(draftProductIDs), nil
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Goods receipt errors
+var (
+	ErrPurchaseOrderNotReceivable = NewError("purchase order is not sent")
+	ErrReceiptProductNotOrdered   = NewError("product is not on the purchase order")
+	ErrReceiptExceedsOrdered      = NewError("received quantity exceeds the quantity still expected")
+)
+
+type purchaseOrderRepository struct {
+	db *database.DB
+}
+
+func NewPurchaseOrderRepository(db *database.DB) PurchaseOrderRepository {
+	return &purchaseOrderRepository{
+		db: db,
+	}
+}
+
+// purchaseOrderColumns is the column list read into model.PurchaseOrder, with the order total
+const purchaseOrderColumns = `
+	po.id, po.number, po.supplier_id, po.status, po.expected_at, COALESCE(po.notes, '') AS notes,
+	po.created_by, po.sent_at, po.received_at, po.created_at, po.updated_at,
+	COALESCE((SELECT SUM(l.quantity_ordered * l.unit_cost) FROM purchase_order_lines l WHERE l.purchase_order_id = po.id), 0) AS total
+`
+
+// insertLines adds the lines of a purchase order. Lines without a unit cost take
+// the cost of the supplier link, or else the cost price of the product.
+func insertLines(tx *sqlx.Tx, po *model.PurchaseOrder, lines []model.PurchaseOrderLineInput) error {
+	query := `
+		INSERT INTO purchase_order_lines (id, purchase_order_id, product_id, quantity_ordered, unit_cost)
+		SELECT $1, $2, p.id, $4,
+		       COALESCE($5, (SELECT ps.unit_cost FROM product_suppliers ps WHERE ps.product_id = p.id AND ps.supplier_id = $6), p.cost_price, 0)
+		FROM products p
+		WHERE p.id = $3
+	`
+
+	for _, line := range lines {
+		_, err := tx.Exec(query, uuid.New(), po.ID, line.ProductID, line.Quantity, line.UnitCost, po.SupplierID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *purchaseOrderRepository) Create(po *model.PurchaseOrder, lines []model.PurchaseOrderLineInput) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO purchase_orders (id, supplier_id, status, expected_at, notes, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING number
+	`
+
+	err = tx.Get(
+		&po.Number,
+		query,
+		po.ID,
+		po.SupplierID,
+		po.Status,
+		po.ExpectedAt,
+		po.Notes,
+		po.CreatedBy,
+		po.CreatedAt,
+		po.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := insertLines(tx, po, lines); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID gets a purchase order with its lines
+func (r *purchaseOrderRepository) GetByID(id uuid.UUID) (*model.PurchaseOrder, error) {
+	var po model.PurchaseOrder
+
+	query := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders po WHERE po.id = $1`
+	if err := r.db.Get(&po, query, id); err != nil {
+		return nil, err
+	}
+
+	linesQuery := `
+		SELECT l.id, l.purchase_order_id, l.product_id, p.sku AS product_sku, p.name AS product_name,
+		       ps.supplier_sku, l.quantity_ordered, l.quantity_received, l.unit_cost
+		FROM purchase_order_lines l
+		JOIN products p ON p.id = l.product_id
+		LEFT JOIN product_suppliers ps ON ps.product_id = l.product_id AND ps.supplier_id = $2
+		WHERE l.purchase_order_id = $1
+		ORDER BY p.name ASC
+	`
+	if err := r.db.Select(&po.Lines, linesQuery, id, po.SupplierID); err != nil {
+		return nil, err
+	}
+
+	return &po, nil
+}
+
+// List lists purchase orders without their lines, newest first
+func (r *purchaseOrderRepository) List(offset, limit int, supplierID *uuid.UUID, status string) ([]model.PurchaseOrder, int, error) {
+	orders := []model.PurchaseOrder{}
+	var total int
+
+	whereClause := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if supplierID != nil {
+		whereClause += " AND po.supplier_id = $" + strconv.Itoa(argIndex)
+		args = append(args, *supplierID)
+		argIndex++
+	}
+	if status != "" {
+		whereClause += " AND po.status = $" + strconv.Itoa(argIndex)
+		args = append(args, status)
+		argIndex++
+	}
+
+	if err := r.db.Get(&total, `SELECT COUNT(*) FROM purchase_orders po`+whereClause, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders po` + whereClause +
+		` ORDER BY po.created_at DESC LIMIT $` + strconv.Itoa(argIndex) + ` OFFSET $` + strconv.Itoa(argIndex+1)
+	args = append(args, limit, offset)
+
+	if err := r.db.Select(&orders, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return orders, total, nil
+}
+
+// Update updates a draft purchase order and replaces its lines
+func (r *purchaseOrderRepository) Update(po *model.PurchaseOrder, lines []model.PurchaseOrderLineInput) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	po.UpdatedAt = time.Now()
+
+	query := `UPDATE purchase_orders SET supplier_id = $1, expected_at = $2, notes = $3, updated_at = $4 WHERE id = $5`
+	if _, err := tx.Exec(query, po.SupplierID, po.ExpectedAt, po.Notes, po.UpdatedAt, po.ID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM purchase_order_lines WHERE purchase_order_id = $1`, po.ID); err != nil {
+		return err
+	}
+
+	if err := insertLines(tx, po, lines); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *purchaseOrderRepository) Delete(id uuid.UUID) error {
+	_, err := r.db.Exec(`DELETE FROM purchase_orders WHERE id = $1`, id)
+	return err
+}
+
+func (r *purchaseOrderRepository) MarkSent(id uuid.UUID, sentAt time.Time) error {
+	query := `UPDATE purchase_orders SET status = $1, sent_at = $2, updated_at = $2 WHERE id = $3`
+	_, err := r.db.Exec(query, model.PurchaseOrderSent, sentAt, id)
+	return err
+}
+
+// Receive books a goods receipt: the received quantities are added to the order lines
+// and to the stock of the receiving warehouse, with a purchase inventory movement per
+// product. Without a warehouse, goods go to the default warehouse. The order
+// becomes received once every line is complete, partially received before that.
+func (r *purchaseOrderRepository) Receive(id uuid.UUID, receipt model.GoodsReceiptInput, receivedBy *uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the order so concurrent receipts are booked one after the other
+	var po struct {
+		Number string `db:"number"`
+		Status string `db:"status"`
+	}
+	if err := tx.Get(&po, `SELECT number, status FROM purchase_orders WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return err
+	}
+	if po.Status != model.PurchaseOrderSent && po.Status != model.PurchaseOrderPartiallyReceived {
+		return ErrPurchaseOrderNotReceivable
+	}
+
+	var warehouseID uuid.UUID
+	if receipt.WarehouseID != nil {
+		warehouseID = *receipt.WarehouseID
+	} else {
+		warehouseID, err = defaultWarehouseID(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	notes := "Goods receipt for purchase order " + po.Number
+	if receipt.Notes != "" {
+		notes += ": " + receipt.Notes
+	}
+
+	for _, line := range receipt.Lines {
+		var remaining int
+		query := `
+			SELECT quantity_ordered - quantity_received
+			FROM purchase_order_lines
+			WHERE purchase_order_id = $1 AND product_id = $2
+		`
+		err := tx.Get(&remaining, query, id, line.ProductID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s", ErrReceiptProductNotOrdered, line.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+		if line.Quantity > remaining {
+			return fmt.Errorf("%w: %s, %d expected", ErrReceiptExceedsOrdered, line.ProductID, remaining)
+		}
+
+		updateLine := `
+			UPDATE purchase_order_lines SET quantity_received = quantity_received + $1
+			WHERE purchase_order_id = $2 AND product_id = $3
+		`
+		if _, err := tx.Exec(updateLine, line.Quantity, id, line.ProductID); err != nil {
+			return err
+		}
+
+		if err := addWarehouseStock(tx, warehouseID, line.ProductID, line.Quantity); err != nil {
+			return err
+		}
+		if err := recordMovement(tx, line.ProductID, warehouseID, line.Quantity, model.MovementPurchase, &id, notes, receivedBy, now); err != nil {
+			return err
+		}
+		if err := syncProductStock(tx, line.ProductID, now); err != nil {
+			return err
+		}
+	}
+
+	var outstanding int
+	outstandingQuery := `SELECT COUNT(*) FROM purchase_order_lines WHERE purchase_order_id = $1 AND quantity_received < quantity_ordered`
+	if err := tx.Get(&outstanding, outstandingQuery, id); err != nil {
+		return err
+	}
+
+	if outstanding == 0 {
+		_, err = tx.Exec(`UPDATE purchase_orders SET status = $1, received_at = $2, updated_at = $2 WHERE id = $3`, model.PurchaseOrderReceived, now, id)
+	} else {
+		_, err = tx.Exec(`UPDATE purchase_orders SET status = $1, updated_at = $2 WHERE id = $3`, model.PurchaseOrderPartiallyReceived, now, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type recentlyViewedRepository struct {
+	db *database.DB
+}
+
+func NewRecentlyViewedRepository(db *database.DB) RecentlyViewedRepository {
+	return &recentlyViewedRepository{
+		db: db,
+	}
+}
+
+// Record stores a product view, moving an already viewed product to the top,
+// and keeps only the latest limit entries of the user.
+func (r *recentlyViewedRepository) Record(userID, productID uuid.UUID, viewedAt time.Time, limit int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO recently_viewed (user_id, product_id, viewed_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, product_id) DO UPDATE SET viewed_at = GREATEST(recently_viewed.viewed_at, EXCLUDED.viewed_at)
+	`
+	if _, err := tx.Exec(query, userID, productID, viewedAt); err != nil {
+		return err
+	}
+
+	trimQuery := `
+		DELETE FROM recently_viewed
+		WHERE user_id = $1 AND product_id IN (
+			SELECT product_id FROM recently_viewed
+			WHERE user_id = $1
+			ORDER BY viewed_at DESC
+			OFFSET $2
+		)
+	`
+	if _, err := tx.Exec(trimQuery, userID, limit); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByUserID gets the recently viewed entries of a user, most recent first, without products
+func (r *recentlyViewedRepository) GetByUserID(userID uuid.UUID, limit int) ([]model.RecentlyViewedItem, error) {
+	var items []model.RecentlyViewedItem
+
+	query := `
+		SELECT user_id, product_id, viewed_at
+		FROM recently_viewed
+		WHERE user_id = $1
+		ORDER BY viewed_at DESC
+		LIMIT $2
+	`
+
+	err := r.db.Select(&items, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *recentlyViewedRepository) Remove(userID, productID uuid.UUID) error {
+	query := `DELETE FROM recently_viewed WHERE user_id = $1 AND product_id = $2`
+	_, err := r.db.Exec(query, userID, productID)
+	return err
+}
+
+func (r *recentlyViewedRepository) Clear(userID uuid.UUID) error {
+	query := `DELETE FROM recently_viewed WHERE user_id = $1`
+	_, err := r.db.Exec(query, userID)
+	return err
+}
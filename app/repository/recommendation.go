@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type recommendationRepository struct {
+	db *database.DB
+}
+
+func NewRecommendationRepository(db *database.DB) RecommendationRepository {
+	return &recommendationRepository{
+		db: db,
+	}
+}
+
+// Recompute rebuilds the item-to-item similarity table. Users interact with a product
+// by wishlisting it or reviewing it with 4 stars or more; two products are similar
+// when the same users interact with both (cosine similarity of their user sets).
+// Only the perProduct most similar products are kept for each product.
+func (r *recommendationRepository) Recompute(computedAt time.Time, perProduct int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM product_similarities`); err != nil {
+		return err
+	}
+
+	query := `
+		WITH interactions AS (
+			SELECT user_id, product_id FROM wishlist
+			UNION
+			SELECT user_id, product_id FROM reviews WHERE is_deleted = FALSE AND moderation_status = 'approved' AND rating >= 4
+		),
+		product_counts AS (
+			SELECT product_id, COUNT(*) AS interaction_count
+			FROM interactions
+			GROUP BY product_id
+		),
+		pairs AS (
+			SELECT a.product_id, b.product_id AS similar_product_id, COUNT(*) AS co_occurrences
+			FROM interactions a
+			JOIN interactions b ON a.user_id = b.user_id AND a.product_id <> b.product_id
+			GROUP BY a.product_id, b.product_id
+		),
+		scored AS (
+			SELECT p.product_id, p.similar_product_id, p.co_occurrences,
+			       p.co_occurrences / SQRT(ca.interaction_count * cb.interaction_count) AS score
+			FROM pairs p
+			JOIN product_counts ca ON ca.product_id = p.product_id
+			JOIN product_counts cb ON cb.product_id = p.similar_product_id
+		),
+		ranked AS (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY score DESC, co_occurrences DESC) AS rank
+			FROM scored
+		)
+		INSERT INTO product_similarities (product_id, similar_product_id, score, co_occurrences, computed_at)
+		SELECT product_id, similar_product_id, score, co_occurrences, $1
+		FROM ranked
+		WHERE rank <= $2
+	`
+
+	if _, err := tx.Exec(query, computedAt, perProduct); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetSimilar gets the active products most similar to the given product. When a user
+// is given, products already in that user's wishlist are left out.
+func (r *recommendationRepository) GetSimilar(productID uuid.UUID, userID *uuid.UUID, limit int) ([]model.Recommendation, error) {
+	var recommendations []model.Recommendation
+
+	query := `
+		SELECT s.similar_product_id AS product_id, s.score
+		FROM product_similarities s
+		JOIN products p ON p.id = s.similar_product_id
+		WHERE s.product_id = $1
+		  AND p.status = 'active' AND p.publish_status = 'published' AND p.is_deleted = FALSE
+		  AND ($2::uuid IS NULL OR NOT EXISTS (
+		      SELECT 1 FROM wishlist w WHERE w.user_id = $2 AND w.product_id = s.similar_product_id
+		  ))
+		ORDER BY s.score DESC
+		LIMIT $3
+	`
+
+	err := r.db.Select(&recommendations, query, productID, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return recommendations, nil
+}
+
+// GetForUser gets personalised recommendations by blending the products similar to
+// each item of the user's wishlist. Wishlisted and inactive products are left out.
+func (r *recommendationRepository) GetForUser(userID uuid.UUID, limit int) ([]model.Recommendation, error) {
+	var recommendations []model.Recommendation
+
+	query := `
+		SELECT s.similar_product_id AS product_id, SUM(s.score) AS score
+		FROM wishlist w
+		JOIN product_similarities s ON s.product_id = w.product_id
+		JOIN products p ON p.id = s.similar_product_id
+		WHERE w.user_id = $1
+		  AND p.status = 'active' AND p.publish_status = 'published' AND p.is_deleted = FALSE
+		  AND NOT EXISTS (
+		      SELECT 1 FROM wishlist own WHERE own.user_id = $1 AND own.product_id = s.similar_product_id
+		  )
+		GROUP BY s.similar_product_id
+		ORDER BY score DESC
+		LIMIT $2
+	`
+
+	err := r.db.Select(&recommendations, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return recommendations, nil
+}
+
+// CopySimilarities gives a product the similarity links of another one, in both
+// directions. The links stand in until the next recomputation.
+func (r *recommendationRepository) CopySimilarities(fromProductID, toProductID uuid.UUID) error {
+	query := `
+		INSERT INTO product_similarities (product_id, similar_product_id, score, co_occurrences, computed_at)
+		SELECT $2, similar_product_id, score, co_occurrences, computed_at
+		FROM product_similarities
+		WHERE product_id = $1 AND similar_product_id <> $2
+		UNION ALL
+		SELECT product_id, $2, score, co_occurrences, computed_at
+		FROM product_similarities
+		WHERE similar_product_id = $1 AND product_id <> $2
+		ON CONFLICT (product_id, similar_product_id) DO NOTHING
+	`
+
+	_, err := r.db.Exec(query, fromProductID, toProductID)
+	return err
+}
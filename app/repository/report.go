@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"strconv"
+)
+
+type reportRepository struct {
+	db *database.DB
+}
+
+func NewReportRepository(db *database.DB) ReportRepository {
+	return &reportRepository{
+		db: db,
+	}
+}
+
+// productMarginsQuery computes the unit margin of every product with a cost price.
+// The effective price is the sale price when it is set below the regular price,
+// as in model.Product.EffectivePrice. $1 is the warehouse of the stock, NULL for all warehouses.
+const productMarginsQuery = `
+	SELECT p.id AS product_id, p.sku, p.name, p.status, p.price, p.sale_price,
+	       e.effective_price, p.cost_price,
+	       e.effective_price - p.cost_price AS unit_margin,
+	       CASE WHEN e.effective_price > 0
+	            THEN ROUND((e.effective_price - p.cost_price) / e.effective_price * 100, 2)
+	            ELSE 0 END AS margin_percent,
+	       s.stock_quantity,
+	       (e.effective_price - p.cost_price) * s.stock_quantity AS stock_margin,
+	       e.effective_price < p.cost_price AS below_cost
+	FROM products p
+	CROSS JOIN LATERAL (
+		SELECT CASE WHEN p.sale_price > 0 AND p.sale_price < p.price THEN p.sale_price ELSE p.price END AS effective_price
+	) e
+	CROSS JOIN LATERAL (
+		SELECT CASE WHEN $1::uuid IS NULL THEN p.stock_quantity
+		            ELSE COALESCE((SELECT ws.quantity FROM warehouse_stock ws WHERE ws.product_id = p.id AND ws.warehouse_id = $1), 0)
+		       END AS stock_quantity
+	) s
+	WHERE p.is_deleted = FALSE AND p.cost_price > 0
+`
+
+// filteredProductMargins returns the product margins query restricted by the filter, with its arguments
+func filteredProductMargins(filter model.MarginReportFilter) (string, []any) {
+	query := `SELECT * FROM (` + productMarginsQuery + `) m WHERE 1=1`
+	args := []any{filter.WarehouseID}
+	argIndex := 2
+
+	if filter.CategoryID != nil {
+		query += " AND EXISTS (SELECT 1 FROM product_categories pc WHERE pc.product_id = m.product_id AND pc.category_id = $" + strconv.Itoa(argIndex) + ")"
+		args = append(args, *filter.CategoryID)
+		argIndex++
+	}
+	if filter.Status != "" {
+		query += " AND m.status = $" + strconv.Itoa(argIndex)
+		args = append(args, filter.Status)
+		argIndex++
+	}
+	if filter.BelowCostOnly {
+		query += " AND m.below_cost"
+	}
+	if filter.MinMarginPercent != nil {
+		query += " AND m.margin_percent >= $" + strconv.Itoa(argIndex)
+		args = append(args, *filter.MinMarginPercent)
+		argIndex++
+	}
+	if filter.MaxMarginPercent != nil {
+		query += " AND m.margin_percent <= $" + strconv.Itoa(argIndex)
+		args = append(args, *filter.MaxMarginPercent)
+		argIndex++
+	}
+
+	return query, args
+}
+
+// GetProductMargins lists the product margins matching the filter. A limit of 0 returns all rows.
+func (r *reportRepository) GetProductMargins(filter model.MarginReportFilter, offset, limit int) ([]model.ProductMargin, *model.MarginSummary, error) {
+	margins := []model.ProductMargin{}
+	var summary model.MarginSummary
+
+	query, args := filteredProductMargins(filter)
+
+	summaryQuery := `
+		SELECT COUNT(*) AS product_count,
+		       COUNT(*) FILTER (WHERE below_cost) AS below_cost_count,
+		       COALESCE(ROUND(AVG(margin_percent), 2), 0) AS average_margin_percent,
+		       COALESCE(SUM(stock_margin), 0) AS stock_margin
+		FROM (` + query + `) f
+	`
+	if err := r.db.Get(&summary, summaryQuery, args...); err != nil {
+		return nil, nil, err
+	}
+
+	// Sort field and order are checked by the controller against a fixed list
+	listQuery := query + " ORDER BY m." + filter.SortBy + " " + filter.SortOrder + ", m.sku ASC"
+	if limit > 0 {
+		listQuery += " LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
+		args = append(args, limit, offset)
+	}
+
+	if err := r.db.Select(&margins, listQuery, args...); err != nil {
+		return nil, nil, err
+	}
+
+	return margins, &summary, nil
+}
+
+// GetCategoryMargins aggregates the margins of the products matching the filter per category
+func (r *reportRepository) GetCategoryMargins(filter model.MarginReportFilter) ([]model.CategoryMargin, error) {
+	margins := []model.CategoryMargin{}
+
+	query, args := filteredProductMargins(filter)
+
+	categoryQuery := `
+		SELECT c.id AS category_id, c.name,
+		       COUNT(*) AS product_count,
+		       ROUND(AVG(m.unit_margin), 2) AS average_unit_margin,
+		       ROUND(AVG(m.margin_percent), 2) AS average_margin_percent,
+		       MIN(m.margin_percent) AS min_margin_percent,
+		       MAX(m.margin_percent) AS max_margin_percent,
+		       SUM(m.stock_margin) AS stock_margin,
+		       COUNT(*) FILTER (WHERE m.below_cost) AS below_cost_count
+		FROM (` + query + `) m
+		JOIN product_categories pc ON pc.product_id = m.product_id
+		JOIN categories c ON c.id = pc.category_id AND c.is_deleted = FALSE
+		GROUP BY c.id, c.name
+		ORDER BY ` + filter.SortBy + ` ` + filter.SortOrder + `, c.name ASC
+	`
+
+	if err := r.db.Select(&margins, categoryQuery, args...); err != nil {
+		return nil, err
+	}
+
+	return margins, nil
+}
+
+// stockLevelsQuery lists the stock of every product in every warehouse, including
+// the warehouses that hold none of it
+const stockLevelsQuery = `
+	SELECT w.id AS warehouse_id, w.code AS warehouse_code,
+	       p.id AS product_id, p.sku, p.name, p.status,
+	       COALESCE(ws.quantity, 0) AS quantity,
+	       COALESCE(p.cost_price, 0) AS cost_price,
+	       COALESCE(ws.quantity, 0) * COALESCE(p.cost_price, 0) AS stock_value
+	FROM products p
+	CROSS JOIN warehouses w
+	LEFT JOIN warehouse_stock ws ON ws.warehouse_id = w.id AND ws.product_id = p.id
+	WHERE p.is_deleted = FALSE AND w.is_deleted = FALSE
+`
+
+// GetStockLevels lists the stock levels matching the filter. A limit of 0 returns all rows.
+func (r *reportRepository) GetStockLevels(filter model.StockReportFilter, offset, limit int) ([]model.StockLevel, *model.StockSummary, error) {
+	levels := []model.StockLevel{}
+	var summary model.StockSummary
+
+	query := `SELECT * FROM (` + stockLevelsQuery + `) l WHERE 1=1`
+	args := []any{}
+	argIndex := 1
+
+	if filter.WarehouseID != nil {
+		query += " AND l.warehouse_id = $" + strconv.Itoa(argIndex)
+		args = append(args, *filter.WarehouseID)
+		argIndex++
+	}
+	if filter.CategoryID != nil {
+		query += " AND EXISTS (SELECT 1 FROM product_categories pc WHERE pc.product_id = l.product_id AND pc.category_id = $" + strconv.Itoa(argIndex) + ")"
+		args = append(args, *filter.CategoryID)
+		argIndex++
+	}
+	if filter.Status != "" {
+		query += " AND l.status = $" + strconv.Itoa(argIndex)
+		args = append(args, filter.Status)
+		argIndex++
+	}
+	if filter.Search != "" {
+		query += " AND (l.sku ILIKE $" + strconv.Itoa(argIndex) + " OR l.name ILIKE $" + strconv.Itoa(argIndex) + ")"
+		args = append(args, "%"+filter.Search+"%")
+		argIndex++
+	}
+	if filter.MaxQuantity != nil {
+		query += " AND l.quantity <= $" + strconv.Itoa(argIndex)
+		args = append(args, *filter.MaxQuantity)
+		argIndex++
+	}
+
+	summaryQuery := `
+		SELECT COUNT(*) AS row_count,
+		       COALESCE(SUM(quantity), 0) AS total_quantity,
+		       COALESCE(SUM(stock_value), 0) AS stock_value,
+		       COUNT(*) FILTER (WHERE quantity = 0) AS out_of_stock
+		FROM (` + query + `) f
+	`
+	if err := r.db.Get(&summary, summaryQuery, args...); err != nil {
+		return nil, nil, err
+	}
+
+	// Sort field and order are checked by the controller against a fixed list
+	listQuery := query + " ORDER BY l." + filter.SortBy + " " + filter.SortOrder + ", l.sku ASC, l.warehouse_code ASC"
+	if limit > 0 {
+		listQuery += " LIMIT $" + strconv.Itoa(argIndex) + " OFFSET $" + strconv.Itoa(argIndex+1)
+		args = append(args, limit, offset)
+	}
+
+	if err := r.db.Select(&levels, listQuery, args...); err != nil {
+		return nil, nil, err
+	}
+
+	return levels, &summary, nil
+}
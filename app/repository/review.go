@@ -1,13 +1,20 @@
 package repository
 
 import (
+	"database/sql"
+	"fmt"
 	"golang-test1/app/model"
 	"golang-test1/platform/database"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 )
 
+// ErrTooManyReviewPhotos is returned when photos would exceed the limit of a review
+var ErrTooManyReviewPhotos = NewError("too many photos on the review")
+
 type reviewRepository struct {
 	db *database.DB
 }
@@ -20,8 +27,8 @@ func NewReviewRepository(db *database.DB) ReviewRepository {
 func (r *reviewRepository) Create(review *model.Review) error {
 	query := `
 		INSERT INTO reviews (id, product_id, user_id, rating, title, comment, 
-                           is_verified_purchase, helpful_votes, created_at, updated_at, is_deleted)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+                           is_verified_purchase, helpful_votes, moderation_status, created_at, updated_at, is_deleted)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	_, err := r.db.Exec(
@@ -34,6 +41,7 @@ func (r *reviewRepository) Create(review *model.Review) error {
 		review.Comment,
 		review.IsVerifiedPurchase,
 		review.HelpfulVotes,
+		review.ModerationStatus,
 		review.CreatedAt,
 		review.UpdatedAt,
 		false,
@@ -58,25 +66,42 @@ func (r *reviewRepository) GetByID(id uuid.UUID) (*model.Review, error) {
 		return nil, err
 	}
 
-	return &review, nil
+	reviews := []model.Review{review}
+	if err := r.attachPhotos(reviews); err != nil {
+		return nil, err
+	}
+
+	return &reviews[0], nil
 }
 
-func (r *reviewRepository) GetByProductID(productID uuid.UUID) ([]model.Review, error) {
+// GetByProductID gets the approved reviews of a product, only those with photos when
+// withPhotos is set, newest first or, with model.ReviewSortMostHelpful, the most helpful first
+func (r *reviewRepository) GetByProductID(productID uuid.UUID, sort string, withPhotos bool) ([]model.Review, error) {
 	var reviews []model.Review
 
+	orderBy := "r.created_at DESC"
+	if sort == model.ReviewSortMostHelpful {
+		orderBy = "r.helpful_votes DESC, r.not_helpful_votes ASC, r.created_at DESC"
+	}
+
 	query := `
 		SELECT r.*, u.username
 		FROM reviews r
 		JOIN users u ON r.user_id = u.id
-		WHERE r.product_id = $1 AND r.is_deleted = FALSE
-		ORDER BY r.created_at DESC
+		WHERE r.product_id = $1 AND r.is_deleted = FALSE AND r.moderation_status = 'approved'
+		  AND ($2 = FALSE OR EXISTS (SELECT 1 FROM review_photos rp WHERE rp.review_id = r.id))
+		ORDER BY ` + orderBy + `
 	`
 
-	err := r.db.Select(&reviews, query, productID)
+	err := r.db.Select(&reviews, query, productID, withPhotos)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := r.attachPhotos(reviews); err != nil {
+		return nil, err
+	}
+
 	return reviews, nil
 }
 
@@ -96,16 +121,21 @@ func (r *reviewRepository) GetByUserID(userID uuid.UUID) ([]model.Review, error)
 		return nil, err
 	}
 
+	if err := r.attachPhotos(reviews); err != nil {
+		return nil, err
+	}
+
 	return reviews, nil
 }
 
+// Update saves the edited review with its moderation status, clearing an earlier rejection reason
 func (r *reviewRepository) Update(review *model.Review) error {
 	review.UpdatedAt = time.Now()
 
 	query := `
 		UPDATE reviews
-		SET rating = $1, title = $2, comment = $3, updated_at = $4
-		WHERE id = $5
+		SET rating = $1, title = $2, comment = $3, moderation_status = $4, rejection_reason = NULL, updated_at = $5
+		WHERE id = $6
 	`
 
 	_, err := r.db.Exec(
@@ -113,6 +143,7 @@ func (r *reviewRepository) Update(review *model.Review) error {
 		review.Rating,
 		review.Title,
 		review.Comment,
+		review.ModerationStatus,
 		review.UpdatedAt,
 		review.ID,
 	)
@@ -126,12 +157,13 @@ func (r *reviewRepository) Delete(id uuid.UUID) error {
 	return err
 }
 
+// List lists the approved reviews, newest first
 func (r *reviewRepository) List(offset, limit int) ([]model.Review, int, error) {
 	var reviews []model.Review
 	var total int
 
 	// Get total count
-	countQuery := `SELECT COUNT(*) FROM reviews`
+	countQuery := `SELECT COUNT(*) FROM reviews WHERE is_deleted = FALSE AND moderation_status = 'approved'`
 	err := r.db.Get(&total, countQuery)
 	if err != nil {
 		return nil, 0, err
@@ -143,6 +175,7 @@ func (r *reviewRepository) List(offset, limit int) ([]model.Review, int, error)
 		FROM reviews r
 		JOIN users u ON r.user_id = u.id
 		JOIN products p ON r.product_id = p.id
+		WHERE r.is_deleted = FALSE AND r.moderation_status = 'approved'
 		ORDER BY r.created_at DESC
 		LIMIT $1 OFFSET $2
 	`
@@ -152,5 +185,382 @@ func (r *reviewRepository) List(offset, limit int) ([]model.Review, int, error)
 		return nil, 0, err
 	}
 
+	if err := r.attachPhotos(reviews); err != nil {
+		return nil, 0, err
+	}
+
 	return reviews, total, nil
 }
+
+// GetRatingStats gets the average rating and review count of the given products,
+// counting approved reviews. Products without reviews are missing from the result.
+func (r *reviewRepository) GetRatingStats(productIDs []uuid.UUID) (map[uuid.UUID]model.RatingStats, error) {
+	summaries, err := getRatingSummaries(r.db, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[uuid.UUID]model.RatingStats, len(summaries))
+	for id, summary := range summaries {
+		if summary.ReviewCount > 0 {
+			stats[id] = model.RatingStats{
+				ProductID:     id,
+				AverageRating: summary.AverageRating,
+				ReviewCount:   summary.ReviewCount,
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// GetRatingSummary gets the rating summary of a product, empty when it has no approved reviews
+func (r *reviewRepository) GetRatingSummary(productID uuid.UUID) (*model.RatingSummary, error) {
+	summaries, err := getRatingSummaries(r.db, []uuid.UUID{productID})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := summaries[productID]
+	return &summary, nil
+}
+
+// getRatingSummaries reads the rating summaries of the given products, kept by a trigger
+// on reviews. Products without approved reviews get an empty summary.
+func getRatingSummaries(db *database.DB, productIDs []uuid.UUID) (map[uuid.UUID]model.RatingSummary, error) {
+	summaries := make(map[uuid.UUID]model.RatingSummary, len(productIDs))
+	if len(productIDs) == 0 {
+		return summaries, nil
+	}
+
+	ids := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		ids[i] = id.String()
+		summaries[id] = model.RatingSummary{
+			ProductID: id,
+			Histogram: map[string]int{"1": 0, "2": 0, "3": 0, "4": 0, "5": 0},
+		}
+	}
+
+	type summaryScan struct {
+		ProductID     uuid.UUID `db:"product_id"`
+		AverageRating float64   `db:"average_rating"`
+		ReviewCount   int       `db:"review_count"`
+		Rating1       int       `db:"rating_1"`
+		Rating2       int       `db:"rating_2"`
+		Rating3       int       `db:"rating_3"`
+		Rating4       int       `db:"rating_4"`
+		Rating5       int       `db:"rating_5"`
+	}
+
+	var rows []summaryScan
+
+	query := `
+		SELECT product_id, average_rating::float8 AS average_rating, review_count,
+		       rating_1, rating_2, rating_3, rating_4, rating_5
+		FROM product_rating_summaries
+		WHERE product_id = ANY($1::uuid[])
+	`
+
+	if err := db.Select(&rows, query, ids); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		summaries[row.ProductID] = model.RatingSummary{
+			ProductID:     row.ProductID,
+			AverageRating: row.AverageRating,
+			ReviewCount:   row.ReviewCount,
+			Histogram: map[string]int{
+				"1": row.Rating1,
+				"2": row.Rating2,
+				"3": row.Rating3,
+				"4": row.Rating4,
+				"5": row.Rating5,
+			},
+		}
+	}
+
+	return summaries, nil
+}
+
+// ListForModeration lists the reviews of the moderation queue, oldest first
+func (r *reviewRepository) ListForModeration(filter model.ReviewModerationFilter, offset, limit int) ([]model.Review, int, error) {
+	reviews := []model.Review{}
+	var total int
+
+	where := ` WHERE r.is_deleted = FALSE`
+	args := []any{}
+	argIndex := 1
+
+	if filter.Status != "" {
+		where += " AND r.moderation_status = $" + strconv.Itoa(argIndex)
+		args = append(args, filter.Status)
+		argIndex++
+	}
+	if filter.ProductID != nil {
+		where += " AND r.product_id = $" + strconv.Itoa(argIndex)
+		args = append(args, *filter.ProductID)
+		argIndex++
+	}
+	if filter.UserID != nil {
+		where += " AND r.user_id = $" + strconv.Itoa(argIndex)
+		args = append(args, *filter.UserID)
+		argIndex++
+	}
+	if filter.Rating != nil {
+		where += " AND r.rating = $" + strconv.Itoa(argIndex)
+		args = append(args, *filter.Rating)
+		argIndex++
+	}
+	if filter.CreatedAfter != nil {
+		where += " AND r.created_at >= $" + strconv.Itoa(argIndex)
+		args = append(args, *filter.CreatedAfter)
+		argIndex++
+	}
+	if filter.CreatedBefore != nil {
+		where += " AND r.created_at <= $" + strconv.Itoa(argIndex)
+		args = append(args, *filter.CreatedBefore)
+		argIndex++
+	}
+
+	if err := r.db.Get(&total, `SELECT COUNT(*) FROM reviews r`+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT r.*, u.username, p.name as product_name
+		FROM reviews r
+		JOIN users u ON r.user_id = u.id
+		JOIN products p ON r.product_id = p.id` + where + `
+		ORDER BY r.created_at ASC, r.id ASC
+		LIMIT $` + strconv.Itoa(argIndex) + ` OFFSET $` + strconv.Itoa(argIndex+1)
+	args = append(args, limit, offset)
+
+	if err := r.db.Select(&reviews, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.attachPhotos(reviews); err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// Moderate approves or rejects reviews, returning the IDs of the reviews found.
+// The reason is only kept for rejections.
+func (r *reviewRepository) Moderate(ids []uuid.UUID, status, reason string, moderatedBy *uuid.UUID, moderatedAt time.Time) ([]uuid.UUID, error) {
+	updated := []uuid.UUID{}
+
+	reviewIDs := make([]string, len(ids))
+	for i, id := range ids {
+		reviewIDs[i] = id.String()
+	}
+
+	var rejectionReason *string
+	if status == model.ReviewStatusRejected {
+		rejectionReason = &reason
+	}
+
+	query := `
+		UPDATE reviews
+		SET moderation_status = $1, rejection_reason = $2, moderated_by = $3, moderated_at = $4
+		WHERE id = ANY($5::uuid[]) AND is_deleted = FALSE
+		RETURNING id
+	`
+	if err := r.db.Select(&updated, query, status, rejectionReason, moderatedBy, moderatedAt, reviewIDs); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// countVotes recomputes the vote counters of a review locked by the transaction. The
+// counters leave updated_at alone, see the reviews modtime trigger.
+func countVotes(tx *sqlx.Tx, reviewID uuid.UUID) (*model.ReviewVote, error) {
+	var vote model.ReviewVote
+
+	query := `
+		UPDATE reviews
+		SET helpful_votes = v.helpful, not_helpful_votes = v.not_helpful
+		FROM (
+			SELECT COUNT(*) FILTER (WHERE is_helpful) AS helpful,
+			       COUNT(*) FILTER (WHERE NOT is_helpful) AS not_helpful
+			FROM review_votes
+			WHERE review_id = $1
+		) v
+		WHERE reviews.id = $1
+		RETURNING reviews.id AS review_id, reviews.helpful_votes, reviews.not_helpful_votes
+	`
+	if err := tx.Get(&vote, query, reviewID); err != nil {
+		return nil, err
+	}
+
+	return &vote, nil
+}
+
+// lockReview locks a review against concurrent votes, sql.ErrNoRows when it is deleted
+func lockReview(tx *sqlx.Tx, reviewID uuid.UUID) error {
+	return tx.QueryRow(`SELECT id FROM reviews WHERE id = $1 AND is_deleted = FALSE FOR UPDATE`, reviewID).Scan(&reviewID)
+}
+
+// Vote records the vote of a user on a review, replacing an earlier vote of the user
+func (r *reviewRepository) Vote(reviewID, userID uuid.UUID, helpful bool) (*model.ReviewVote, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockReview(tx, reviewID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	query := `
+		INSERT INTO review_votes (review_id, user_id, is_helpful, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (review_id, user_id) DO UPDATE SET is_helpful = EXCLUDED.is_helpful, updated_at = EXCLUDED.updated_at
+	`
+	if _, err := tx.Exec(query, reviewID, userID, helpful, now); err != nil {
+		return nil, err
+	}
+
+	vote, err := countVotes(tx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	vote.Helpful = &helpful
+
+	return vote, tx.Commit()
+}
+
+// DeleteVote removes the vote of a user on a review, sql.ErrNoRows when there is none
+func (r *reviewRepository) DeleteVote(reviewID, userID uuid.UUID) (*model.ReviewVote, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockReview(tx, reviewID); err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(`DELETE FROM review_votes WHERE review_id = $1 AND user_id = $2`, reviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if deleted == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	vote, err := countVotes(tx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	return vote, tx.Commit()
+}
+
+// attachPhotos loads the photos of all given reviews with a single query, oldest first
+func (r *reviewRepository) attachPhotos(reviews []model.Review) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(reviews))
+	for i := range reviews {
+		ids[i] = reviews[i].ID.String()
+	}
+
+	var photos []model.ReviewPhoto
+
+	query := `
+		SELECT id, review_id, storage_key, content_type, size_bytes, created_at
+		FROM review_photos
+		WHERE review_id = ANY($1::uuid[])
+		ORDER BY created_at ASC, id ASC
+	`
+
+	if err := r.db.Select(&photos, query, ids); err != nil {
+		return err
+	}
+
+	byReview := make(map[uuid.UUID][]model.ReviewPhoto, len(reviews))
+	for _, photo := range photos {
+		byReview[photo.ReviewID] = append(byReview[photo.ReviewID], photo)
+	}
+
+	for i := range reviews {
+		reviews[i].Photos = byReview[reviews[i].ID]
+		if reviews[i].Photos == nil {
+			reviews[i].Photos = []model.ReviewPhoto{}
+		}
+	}
+
+	return nil
+}
+
+// AddPhotos attaches stored photos to a review, at most maxCount in all, and puts the
+// review back into moderation
+func (r *reviewRepository) AddPhotos(reviewID uuid.UUID, photos []model.ReviewPhoto, maxCount int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockReview(tx, reviewID); err != nil {
+		return err
+	}
+
+	var count int
+	if err := tx.Get(&count, `SELECT COUNT(*) FROM review_photos WHERE review_id = $1`, reviewID); err != nil {
+		return err
+	}
+	if count+len(photos) > maxCount {
+		return fmt.Errorf("%w: at most %d allowed", ErrTooManyReviewPhotos, maxCount)
+	}
+
+	query := `
+		INSERT INTO review_photos (id, review_id, storage_key, content_type, size_bytes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for _, photo := range photos {
+		if _, err := tx.Exec(query, photo.ID, reviewID, photo.StorageKey, photo.ContentType, photo.SizeBytes, photo.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	query = `
+		UPDATE reviews
+		SET moderation_status = $1, rejection_reason = NULL, updated_at = $2
+		WHERE id = $3
+	`
+	if _, err := tx.Exec(query, model.ReviewStatusPending, time.Now(), reviewID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeletePhoto removes a photo from a review and returns it, so its file can be deleted.
+// sql.ErrNoRows when the review has no such photo.
+func (r *reviewRepository) DeletePhoto(reviewID, photoID uuid.UUID) (*model.ReviewPhoto, error) {
+	var photo model.ReviewPhoto
+
+	query := `
+		DELETE FROM review_photos
+		WHERE id = $1 AND review_id = $2
+		RETURNING id, review_id, storage_key, content_type, size_bytes, created_at
+	`
+	if err := r.db.Get(&photo, query, photoID, reviewID); err != nil {
+		return nil, err
+	}
+
+	return &photo, nil
+}
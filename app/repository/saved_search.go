@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"encoding/json"
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type savedSearchRepository struct {
+	db *database.DB
+}
+
+func NewSavedSearchRepository(db *database.DB) SavedSearchRepository {
+	return &savedSearchRepository{
+		db: db,
+	}
+}
+
+// savedSearchColumns is the column list read into savedSearchScan
+const savedSearchColumns = `id, user_id, name, filters::text AS filters, notify, last_checked_at, created_at, updated_at`
+
+type savedSearchScan struct {
+	ID            uuid.UUID `db:"id"`
+	UserID        uuid.UUID `db:"user_id"`
+	Name          string    `db:"name"`
+	Filters       string    `db:"filters"`
+	Notify        bool      `db:"notify"`
+	LastCheckedAt time.Time `db:"last_checked_at"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+func (s savedSearchScan) toModel() (model.SavedSearch, error) {
+	savedSearch := model.SavedSearch{
+		ID:            s.ID,
+		UserID:        s.UserID,
+		Name:          s.Name,
+		Notify:        s.Notify,
+		LastCheckedAt: s.LastCheckedAt,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+	}
+	err := json.Unmarshal([]byte(s.Filters), &savedSearch.Filters)
+	return savedSearch, err
+}
+
+func (r *savedSearchRepository) selectSavedSearches(query string, args ...any) ([]model.SavedSearch, error) {
+	var rows []savedSearchScan
+	if err := r.db.Select(&rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	savedSearches := make([]model.SavedSearch, len(rows))
+	for i, row := range rows {
+		savedSearch, err := row.toModel()
+		if err != nil {
+			return nil, err
+		}
+		savedSearches[i] = savedSearch
+	}
+
+	return savedSearches, nil
+}
+
+func (r *savedSearchRepository) Create(savedSearch *model.SavedSearch) error {
+	filtersJSON, err := json.Marshal(savedSearch.Filters)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO saved_searches (id, user_id, name, filters, notify, last_checked_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = r.db.Exec(
+		query,
+		savedSearch.ID,
+		savedSearch.UserID,
+		savedSearch.Name,
+		filtersJSON,
+		savedSearch.Notify,
+		savedSearch.LastCheckedAt,
+		savedSearch.CreatedAt,
+		savedSearch.UpdatedAt,
+	)
+	return err
+}
+
+// GetByID returns a saved search of the user
+func (r *savedSearchRepository) GetByID(userID, id uuid.UUID) (*model.SavedSearch, error) {
+	var row savedSearchScan
+
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE id = $1 AND user_id = $2`
+	if err := r.db.Get(&row, query, id, userID); err != nil {
+		return nil, err
+	}
+
+	savedSearch, err := row.toModel()
+	if err != nil {
+		return nil, err
+	}
+
+	return &savedSearch, nil
+}
+
+func (r *savedSearchRepository) GetByUserID(userID uuid.UUID) ([]model.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE user_id = $1 ORDER BY name ASC`
+	return r.selectSavedSearches(query, userID)
+}
+
+// ExistsByName reports whether the user has another saved search with the given name
+func (r *savedSearchRepository) ExistsByName(userID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error) {
+	var exists bool
+
+	query := `SELECT EXISTS(SELECT 1 FROM saved_searches WHERE user_id = $1 AND name = $2 AND ($3::uuid IS NULL OR id <> $3))`
+	err := r.db.Get(&exists, query, userID, name, excludeID)
+	return exists, err
+}
+
+func (r *savedSearchRepository) Update(savedSearch *model.SavedSearch) error {
+	filtersJSON, err := json.Marshal(savedSearch.Filters)
+	if err != nil {
+		return err
+	}
+
+	savedSearch.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE saved_searches
+		SET name = $1, filters = $2, notify = $3, last_checked_at = $4, updated_at = $5
+		WHERE id = $6 AND user_id = $7
+	`
+
+	_, err = r.db.Exec(
+		query,
+		savedSearch.Name,
+		filtersJSON,
+		savedSearch.Notify,
+		savedSearch.LastCheckedAt,
+		savedSearch.UpdatedAt,
+		savedSearch.ID,
+		savedSearch.UserID,
+	)
+	return err
+}
+
+func (r *savedSearchRepository) Delete(userID, id uuid.UUID) error {
+	_, err := r.db.Exec(`DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`, id, userID)
+	return err
+}
+
+// GetNotifiable returns the saved searches whose users opted into new-match notifications
+func (r *savedSearchRepository) GetNotifiable() ([]model.SavedSearch, error) {
+	query := `
+		SELECT ` + savedSearchColumns + `
+		FROM saved_searches s
+		WHERE s.notify AND EXISTS (SELECT 1 FROM users u WHERE u.id = s.user_id AND u.is_active AND NOT u.is_deleted)
+		ORDER BY s.last_checked_at ASC
+	`
+	return r.selectSavedSearches(query)
+}
+
+// MarkChecked records that the products created up to checkedAt were matched against the saved search
+func (r *savedSearchRepository) MarkChecked(id uuid.UUID, checkedAt time.Time) error {
+	_, err := r.db.Exec(`UPDATE saved_searches SET last_checked_at = $1 WHERE id = $2`, checkedAt, id)
+	return err
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"encoding/json"
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type searchRepository struct {
+	db *database.DB
+}
+
+func NewSearchRepository(db *database.DB) SearchRepository {
+	return &searchRepository{
+		db: db,
+	}
+}
+
+// likeEscaper escapes the LIKE wildcards of user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SuggestProducts gets active product names starting with the prefix, or having a word
+// starting with it. Names starting with the prefix rank first, then the most popular
+// products, popularity being the number of wishlists and approved reviews, both kept up
+// to date by triggers. Both name conditions are served by the trigram index on lower(name).
+func (r *searchRepository) SuggestProducts(prefix string, limit int) ([]model.Suggestion, error) {
+	suggestions := []model.Suggestion{}
+
+	query := `
+		SELECT p.id, p.name AS text,
+		       COALESCE(wc.wishlist_count, 0) + COALESCE(rs.review_count, 0) AS popularity
+		FROM products p
+		LEFT JOIN product_wishlist_counts wc ON wc.product_id = p.id
+		LEFT JOIN product_rating_summaries rs ON rs.product_id = p.id
+		WHERE p.status = 'active' AND p.publish_status = 'published' AND p.is_deleted = FALSE
+		  AND (lower(p.name) LIKE $1 || '%' OR lower(p.name) LIKE '% ' || $1 || '%')
+		ORDER BY lower(p.name) LIKE $1 || '%' DESC, popularity DESC, p.name ASC
+		LIMIT $2
+	`
+
+	err := r.db.Select(&suggestions, query, likeEscaper.Replace(strings.ToLower(prefix)), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+// SuggestSKUs gets the SKUs of active products starting with the prefix, most popular
+// first. The prefix is served by the text_pattern_ops index on lower(sku).
+func (r *searchRepository) SuggestSKUs(prefix string, limit int) ([]model.Suggestion, error) {
+	suggestions := []model.Suggestion{}
+
+	query := `
+		SELECT p.id, p.sku AS text,
+		       COALESCE(wc.wishlist_count, 0) + COALESCE(rs.review_count, 0) AS popularity
+		FROM products p
+		LEFT JOIN product_wishlist_counts wc ON wc.product_id = p.id
+		LEFT JOIN product_rating_summaries rs ON rs.product_id = p.id
+		WHERE p.status = 'active' AND p.publish_status = 'published' AND p.is_deleted = FALSE
+		  AND lower(p.sku) LIKE $1 || '%'
+		ORDER BY popularity DESC, p.sku ASC
+		LIMIT $2
+	`
+
+	err := r.db.Select(&suggestions, query, likeEscaper.Replace(strings.ToLower(prefix)), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+// SuggestCategories gets active category names starting with the prefix, or having a word
+// starting with it, the categories with the most products first
+func (r *searchRepository) SuggestCategories(prefix string, limit int) ([]model.Suggestion, error) {
+	suggestions := []model.Suggestion{}
+
+	query := `
+		SELECT c.id, c.name AS text,
+		       (SELECT COUNT(*) FROM product_categories pc WHERE pc.category_id = c.id) AS popularity
+		FROM categories c
+		WHERE c.is_active = TRUE AND c.is_deleted = FALSE
+		  AND (lower(c.name) LIKE $1 || '%' OR lower(c.name) LIKE '% ' || $1 || '%')
+		ORDER BY lower(c.name) LIKE $1 || '%' DESC, popularity DESC, c.name ASC
+		LIMIT $2
+	`
+
+	err := r.db.Select(&suggestions, query, likeEscaper.Replace(strings.ToLower(prefix)), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+// synonymColumns is the column list read into synonymScan
+const synonymColumns = `id, array_to_json(terms) AS terms, created_at, updated_at`
+
+type synonymScan struct {
+	ID        uuid.UUID `db:"id"`
+	Terms     string    `db:"terms"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (s synonymScan) toModel() (model.SearchSynonym, error) {
+	synonym := model.SearchSynonym{
+		ID:        s.ID,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+	err := json.Unmarshal([]byte(s.Terms), &synonym.Terms)
+	return synonym, err
+}
+
+// NormalizeSearchTerm trims and lower-cases a search term, the form used for
+// synonym matching and search analytics
+func NormalizeSearchTerm(term string) string {
+	return strings.ToLower(strings.TrimSpace(term))
+}
+
+// TruncateRunes shortens s to at most n runes
+func TruncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// searchLogQuery normalizes a search term for the analytics tables, cut to the
+// length of their query column
+func searchLogQuery(query string) string {
+	return TruncateRunes(NormalizeSearchTerm(query), 100)
+}
+
+func (r *searchRepository) CreateSynonym(synonym *model.SearchSynonym) error {
+	query := `
+		INSERT INTO search_synonyms (id, terms, created_at, updated_at)
+		VALUES ($1, $2::text[], $3, $4)
+	`
+
+	_, err := r.db.Exec(query, synonym.ID, synonym.Terms, synonym.CreatedAt, synonym.UpdatedAt)
+	return err
+}
+
+func (r *searchRepository) GetSynonym(id uuid.UUID) (*model.SearchSynonym, error) {
+	var row synonymScan
+
+	query := `SELECT ` + synonymColumns + ` FROM search_synonyms WHERE id = $1`
+	if err := r.db.Get(&row, query, id); err != nil {
+		return nil, err
+	}
+
+	synonym, err := row.toModel()
+	if err != nil {
+		return nil, err
+	}
+
+	return &synonym, nil
+}
+
+func (r *searchRepository) ListSynonyms() ([]model.SearchSynonym, error) {
+	var rows []synonymScan
+
+	query := `SELECT ` + synonymColumns + ` FROM search_synonyms ORDER BY terms[1] ASC`
+	if err := r.db.Select(&rows, query); err != nil {
+		return nil, err
+	}
+
+	synonyms := make([]model.SearchSynonym, len(rows))
+	for i, row := range rows {
+		synonym, err := row.toModel()
+		if err != nil {
+			return nil, err
+		}
+		synonyms[i] = synonym
+	}
+
+	return synonyms, nil
+}
+
+func (r *searchRepository) UpdateSynonym(synonym *model.SearchSynonym) error {
+	synonym.UpdatedAt = time.Now()
+
+	query := `UPDATE search_synonyms SET terms = $1::text[], updated_at = $2 WHERE id = $3`
+	_, err := r.db.Exec(query, synonym.Terms, synonym.UpdatedAt, synonym.ID)
+	return err
+}
+
+func (r *searchRepository) DeleteSynonym(id uuid.UUID) error {
+	_, err := r.db.Exec(`DELETE FROM search_synonyms WHERE id = $1`, id)
+	return err
+}
+
+// LogSearch records a product search and the number of results it returned
+func (r *searchRepository) LogSearch(query string, resultCount int, userID *uuid.UUID) error {
+	insertQuery := `
+		INSERT INTO search_logs (id, query, result_count, user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.Exec(insertQuery, uuid.New(), searchLogQuery(query), resultCount, userID, time.Now())
+	return err
+}
+
+// LogClick records a click on a search result
+func (r *searchRepository) LogClick(query string, productID uuid.UUID, userID *uuid.UUID) error {
+	insertQuery := `
+		INSERT INTO search_clicks (id, query, product_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.Exec(insertQuery, uuid.New(), searchLogQuery(query), productID, userID, time.Now())
+	return err
+}
+
+// GetQueryStats aggregates the searches made in the given period per query, most
+// searched first. With zeroResultsOnly, only queries that returned nothing at least
+// once are listed.
+func (r *searchRepository) GetQueryStats(from, to time.Time, zeroResultsOnly bool, offset, limit int) ([]model.SearchQueryStat, int, error) {
+	stats := []model.SearchQueryStat{}
+	var total int
+
+	having := ""
+	if zeroResultsOnly {
+		having = " HAVING COUNT(*) FILTER (WHERE l.result_count = 0) > 0"
+	}
+
+	aggregateQuery := `
+		SELECT l.query,
+		       COUNT(*) AS searches,
+		       COUNT(*) FILTER (WHERE l.result_count = 0) AS zero_result_searches,
+		       AVG(l.result_count)::float8 AS average_results,
+		       MAX(l.created_at) AS last_searched_at
+		FROM search_logs l
+		WHERE l.created_at >= $1 AND l.created_at <= $2
+		GROUP BY l.query` + having
+
+	countQuery := `SELECT COUNT(*) FROM (` + aggregateQuery + `) q`
+	if err := r.db.Get(&total, countQuery, from, to); err != nil {
+		return nil, 0, err
+	}
+
+	listQuery := `
+		SELECT q.*,
+		       (SELECT COUNT(*) FROM search_clicks c
+		        WHERE c.query = q.query AND c.created_at >= $1 AND c.created_at <= $2) AS clicks
+		FROM (` + aggregateQuery + `) q
+		ORDER BY q.searches DESC, q.query ASC
+		LIMIT $3 OFFSET $4
+	`
+	if err := r.db.Select(&stats, listQuery, from, to, limit, offset); err != nil {
+		return nil, 0, err
+	}
+
+	return stats, total, nil
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"fmt"
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrStockCountNotOpen      = NewError("stock count is not open")
+	ErrStockCountNotSubmitted = NewError("stock count is not submitted")
+	ErrProductNotInCount      = NewError("product is not part of the stock count")
+)
+
+type stockCountRepository struct {
+	db *database.DB
+}
+
+func NewStockCountRepository(db *database.DB) StockCountRepository {
+	return &stockCountRepository{
+		db: db,
+	}
+}
+
+const stockCountQuery = `
+	SELECT sc.*, w.code AS warehouse_code,
+	       (SELECT COUNT(*) FROM stock_count_lines l WHERE l.stock_count_id = sc.id) AS line_count,
+	       (SELECT COUNT(*) FROM stock_count_lines l WHERE l.stock_count_id = sc.id AND l.counted_quantity IS NOT NULL) AS counted_count
+	FROM stock_counts sc
+	JOIN warehouses w ON w.id = sc.warehouse_id
+`
+
+func (r *stockCountRepository) Create(count *model.StockCount, productIDs []uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO stock_counts (id, warehouse_id, status, notes, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = tx.Exec(
+		query,
+		count.ID,
+		count.WarehouseID,
+		count.Status,
+		count.Notes,
+		count.CreatedBy,
+		count.CreatedAt,
+		count.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	idStrings := make([]string, len(productIDs))
+	for i, productID := range productIDs {
+		idStrings[i] = productID.String()
+	}
+
+	linesQuery := `
+		INSERT INTO stock_count_lines (stock_count_id, product_id)
+		SELECT $1, id FROM products WHERE id = ANY($2::uuid[]) AND is_deleted = FALSE
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(linesQuery, count.ID, idStrings); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID gets a count session with its lines
+func (r *stockCountRepository) GetByID(id uuid.UUID) (*model.StockCount, error) {
+	var count model.StockCount
+
+	if err := r.db.Get(&count, stockCountQuery+` WHERE sc.id = $1`, id); err != nil {
+		return nil, err
+	}
+
+	count.Lines = []model.StockCountLine{}
+	linesQuery := `
+		SELECT l.product_id, p.sku AS product_sku, p.name AS product_name,
+		       l.expected_quantity, l.counted_quantity, l.counted_by, l.counted_at
+		FROM stock_count_lines l
+		JOIN products p ON p.id = l.product_id
+		WHERE l.stock_count_id = $1
+		ORDER BY p.sku ASC
+	`
+	if err := r.db.Select(&count.Lines, linesQuery, id); err != nil {
+		return nil, err
+	}
+
+	return &count, nil
+}
+
+func (r *stockCountRepository) List(offset, limit int, warehouseID *uuid.UUID, status string) ([]model.StockCount, int, error) {
+	counts := []model.StockCount{}
+	var total int
+
+	where := ` WHERE 1=1`
+	args := []any{}
+	argIndex := 1
+
+	if warehouseID != nil {
+		where += " AND sc.warehouse_id = $" + strconv.Itoa(argIndex)
+		args = append(args, *warehouseID)
+		argIndex++
+	}
+	if status != "" {
+		where += " AND sc.status = $" + strconv.Itoa(argIndex)
+		args = append(args, status)
+		argIndex++
+	}
+
+	if err := r.db.Get(&total, `SELECT COUNT(*) FROM stock_counts sc`+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := stockCountQuery + where + ` ORDER BY sc.created_at DESC LIMIT $` + strconv.Itoa(argIndex) + ` OFFSET $` + strconv.Itoa(argIndex+1)
+	args = append(args, limit, offset)
+	if err := r.db.Select(&counts, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return counts, total, nil
+}
+
+// RecordCounts stores counted quantities, with the system stock of the warehouse at
+// the time of counting as the expected quantity
+func (r *stockCountRepository) RecordCounts(id uuid.UUID, entries []model.StockCountEntryInput, countedBy *uuid.UUID, countedAt time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var count struct {
+		WarehouseID uuid.UUID `db:"warehouse_id"`
+		Status      string    `db:"status"`
+	}
+	if err := tx.Get(&count, `SELECT warehouse_id, status FROM stock_counts WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return err
+	}
+	if count.Status != model.StockCountOpen {
+		return ErrStockCountNotOpen
+	}
+
+	query := `
+		UPDATE stock_count_lines
+		SET counted_quantity = $1, counted_by = $2, counted_at = $3,
+		    expected_quantity = COALESCE((SELECT quantity FROM warehouse_stock WHERE warehouse_id = $4 AND product_id = $6), 0)
+		WHERE stock_count_id = $5 AND product_id = $6
+	`
+	for _, entry := range entries {
+		result, err := tx.Exec(query, entry.CountedQuantity, countedBy, countedAt, count.WarehouseID, id, entry.ProductID)
+		if err != nil {
+			return err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return fmt.Errorf("%w: %s", ErrProductNotInCount, entry.ProductID)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Submit closes counting, once every product is counted
+func (r *stockCountRepository) Submit(id uuid.UUID, submittedAt time.Time) error {
+	query := `UPDATE stock_counts SET status = $1, submitted_at = $2, updated_at = $2 WHERE id = $3 AND status = $4`
+	_, err := r.db.Exec(query, model.StockCountSubmitted, submittedAt, id, model.StockCountOpen)
+	return err
+}
+
+// Cancel cancels a session that is not approved, leaving stock untouched
+func (r *stockCountRepository) Cancel(id uuid.UUID) error {
+	query := `UPDATE stock_counts SET status = $1, updated_at = $2 WHERE id = $3 AND status IN ($4, $5)`
+	_, err := r.db.Exec(query, model.StockCountCancelled, time.Now(), id, model.StockCountOpen, model.StockCountSubmitted)
+	return err
+}
+
+// GetVariances compares the counted quantities of a session with the system stock,
+// the products without a variance included
+func (r *stockCountRepository) GetVariances(id uuid.UUID) ([]model.StockVariance, *model.StockVarianceSummary, error) {
+	variances := []model.StockVariance{}
+	var summary model.StockVarianceSummary
+
+	query := `
+		SELECT l.product_id, p.sku, p.name,
+		       l.expected_quantity, l.counted_quantity,
+		       l.counted_quantity - l.expected_quantity AS variance,
+		       COALESCE(p.cost_price, 0) AS cost_price,
+		       (l.counted_quantity - l.expected_quantity) * COALESCE(p.cost_price, 0) AS variance_value
+		FROM stock_count_lines l
+		JOIN products p ON p.id = l.product_id
+		WHERE l.stock_count_id = $1 AND l.counted_quantity IS NOT NULL
+	`
+
+	summaryQuery := `
+		SELECT COUNT(*) AS counted_count,
+		       COUNT(*) FILTER (WHERE variance <> 0) AS variance_count,
+		       COALESCE(SUM(variance), 0) AS net_variance,
+		       COALESCE(SUM(variance_value), 0) AS variance_value
+		FROM (` + query + `) v
+	`
+	if err := r.db.Get(&summary, summaryQuery, id); err != nil {
+		return nil, nil, err
+	}
+
+	// Largest differences first
+	if err := r.db.Select(&variances, query+` ORDER BY ABS(l.counted_quantity - l.expected_quantity) DESC, p.sku ASC`, id); err != nil {
+		return nil, nil, err
+	}
+
+	return variances, &summary, nil
+}
+
+// Approve posts the variances of a submitted session: each difference becomes an
+// adjustment movement referencing the session and the warehouse stock follows.
+func (r *stockCountRepository) Approve(id uuid.UUID, approvedBy *uuid.UUID, approvedAt time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var count struct {
+		WarehouseID uuid.UUID `db:"warehouse_id"`
+		Status      string    `db:"status"`
+	}
+	if err := tx.Get(&count, `SELECT warehouse_id, status FROM stock_counts WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return err
+	}
+	if count.Status != model.StockCountSubmitted {
+		return ErrStockCountNotSubmitted
+	}
+
+	var lines []struct {
+		ProductID uuid.UUID `db:"product_id"`
+		Variance  int       `db:"variance"`
+	}
+	linesQuery := `
+		SELECT product_id, counted_quantity - expected_quantity AS variance
+		FROM stock_count_lines
+		WHERE stock_count_id = $1 AND counted_quantity IS NOT NULL AND counted_quantity <> expected_quantity
+	`
+	if err := tx.Select(&lines, linesQuery, id); err != nil {
+		return err
+	}
+
+	notes := "Stock count " + id.String()
+	for _, line := range lines {
+		if err := addWarehouseStock(tx, count.WarehouseID, line.ProductID, line.Variance); err != nil {
+			return fmt.Errorf("%w: %s", err, line.ProductID)
+		}
+		if err := recordMovement(tx, line.ProductID, count.WarehouseID, line.Variance, model.MovementAdjustment, &id, notes, approvedBy, approvedAt); err != nil {
+			return err
+		}
+		if err := syncProductStock(tx, line.ProductID, approvedAt); err != nil {
+			return err
+		}
+	}
+
+	query := `UPDATE stock_counts SET status = $1, approved_by = $2, approved_at = $3, updated_at = $3 WHERE id = $4`
+	if _, err := tx.Exec(query, model.StockCountApproved, approvedBy, approvedAt, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrSupplierHasPurchaseOrders = NewError("supplier has open purchase orders")
+
+type supplierRepository struct {
+	db *database.DB
+}
+
+func NewSupplierRepository(db *database.DB) SupplierRepository {
+	return &supplierRepository{
+		db: db,
+	}
+}
+
+func (r *supplierRepository) Create(supplier *model.Supplier) error {
+	query := `
+		INSERT INTO suppliers (id, name, email, phone, address, is_active, created_at, updated_at, is_deleted)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		supplier.ID,
+		supplier.Name,
+		supplier.Email,
+		supplier.Phone,
+		supplier.Address,
+		supplier.IsActive,
+		supplier.CreatedAt,
+		supplier.UpdatedAt,
+		false,
+	)
+	return err
+}
+
+func (r *supplierRepository) GetByID(id uuid.UUID) (*model.Supplier, error) {
+	var supplier model.Supplier
+
+	query := `SELECT * FROM suppliers WHERE id = $1 AND is_deleted = FALSE`
+	if err := r.db.Get(&supplier, query, id); err != nil {
+		return nil, err
+	}
+
+	return &supplier, nil
+}
+
+func (r *supplierRepository) List(offset, limit int, search string) ([]model.Supplier, int, error) {
+	suppliers := []model.Supplier{}
+	var total int
+
+	where := ` WHERE is_deleted = FALSE AND ($1 = '' OR name ILIKE '%' || $1 || '%' OR email ILIKE '%' || $1 || '%')`
+
+	if err := r.db.Get(&total, `SELECT COUNT(*) FROM suppliers`+where, search); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT * FROM suppliers` + where + ` ORDER BY name ASC LIMIT $2 OFFSET $3`
+	if err := r.db.Select(&suppliers, query, search, limit, offset); err != nil {
+		return nil, 0, err
+	}
+
+	return suppliers, total, nil
+}
+
+func (r *supplierRepository) Update(supplier *model.Supplier) error {
+	supplier.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE suppliers
+		SET name = $1, email = $2, phone = $3, address = $4, is_active = $5, updated_at = $6
+		WHERE id = $7
+	`
+
+	_, err := r.db.Exec(
+		query,
+		supplier.Name,
+		supplier.Email,
+		supplier.Phone,
+		supplier.Address,
+		supplier.IsActive,
+		supplier.UpdatedAt,
+		supplier.ID,
+	)
+	return err
+}
+
+// Delete soft deletes a supplier, keeping its purchase order history
+func (r *supplierRepository) Delete(id uuid.UUID) error {
+	var open int
+	query := `SELECT COUNT(*) FROM purchase_orders WHERE supplier_id = $1 AND status <> $2`
+	if err := r.db.Get(&open, query, id, model.PurchaseOrderReceived); err != nil {
+		return err
+	}
+	if open > 0 {
+		return ErrSupplierHasPurchaseOrders
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM product_suppliers WHERE supplier_id = $1`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE suppliers SET is_deleted = TRUE, is_active = FALSE, updated_at = $1 WHERE id = $2`, time.Now(), id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// productSupplierQuery selects product supplier links with the product and supplier names
+const productSupplierQuery = `
+	SELECT ps.product_id, ps.supplier_id, COALESCE(ps.supplier_sku, '') AS supplier_sku, ps.lead_time_days,
+	       ps.unit_cost, ps.is_preferred, p.sku AS product_sku, p.name AS product_name,
+	       s.name AS supplier_name, ps.created_at, ps.updated_at
+	FROM product_suppliers ps
+	JOIN products p ON p.id = ps.product_id
+	JOIN suppliers s ON s.id = ps.supplier_id
+`
+
+func (r *supplierRepository) GetProducts(supplierID uuid.UUID) ([]model.ProductSupplier, error) {
+	links := []model.ProductSupplier{}
+
+	query := productSupplierQuery + ` WHERE ps.supplier_id = $1 ORDER BY p.name ASC`
+	if err := r.db.Select(&links, query, supplierID); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// GetByProductID returns the suppliers of a product, preferred supplier first
+func (r *supplierRepository) GetByProductID(productID uuid.UUID) ([]model.ProductSupplier, error) {
+	links := []model.ProductSupplier{}
+
+	query := productSupplierQuery + ` WHERE ps.product_id = $1 AND s.is_deleted = FALSE ORDER BY ps.is_preferred DESC, ps.lead_time_days ASC`
+	if err := r.db.Select(&links, query, productID); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (r *supplierRepository) GetLink(productID, supplierID uuid.UUID) (*model.ProductSupplier, error) {
+	var link model.ProductSupplier
+
+	query := productSupplierQuery + ` WHERE ps.product_id = $1 AND ps.supplier_id = $2`
+	if err := r.db.Get(&link, query, productID, supplierID); err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// SaveLink creates or updates a product supplier link. A preferred link
+// makes the other suppliers of the product non preferred.
+func (r *supplierRepository) SaveLink(link *model.ProductSupplier) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if link.IsPreferred {
+		query := `UPDATE product_suppliers SET is_preferred = FALSE WHERE product_id = $1 AND supplier_id <> $2 AND is_preferred`
+		if _, err := tx.Exec(query, link.ProductID, link.SupplierID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO product_suppliers (product_id, supplier_id, supplier_sku, lead_time_days, unit_cost, is_preferred)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (product_id, supplier_id) DO UPDATE
+		SET supplier_sku = EXCLUDED.supplier_sku, lead_time_days = EXCLUDED.lead_time_days,
+		    unit_cost = EXCLUDED.unit_cost, is_preferred = EXCLUDED.is_preferred
+	`
+	if _, err := tx.Exec(query, link.ProductID, link.SupplierID, link.SupplierSKU, link.LeadTimeDays, link.UnitCost, link.IsPreferred); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *supplierRepository) DeleteLink(productID, supplierID uuid.UUID) error {
+	_, err := r.db.Exec(`DELETE FROM product_suppliers WHERE product_id = $1 AND supplier_id = $2`, productID, supplierID)
+	return err
+}
@@ -0,0 +1,384 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"golang-test1/app/model"
+	"golang-test1/platform/database"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrWarehouseIsDefault = NewError("the default warehouse cannot be deleted")
+	ErrWarehouseHasStock  = NewError("warehouse still holds stock")
+	ErrInsufficientStock  = NewError("not enough stock in the warehouse")
+	ErrStockAllocated     = NewError("stock quantity is below the stock held in other warehouses")
+)
+
+type warehouseRepository struct {
+	db *database.DB
+}
+
+func NewWarehouseRepository(db *database.DB) WarehouseRepository {
+	return &warehouseRepository{
+		db: db,
+	}
+}
+
+// defaultWarehouseID gets the ID of the default warehouse within the transaction
+func defaultWarehouseID(tx *sqlx.Tx) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := tx.Get(&id, `SELECT id FROM warehouses WHERE is_default AND is_deleted = FALSE`)
+	return id, err
+}
+
+// addWarehouseStock changes the stock of a product in a warehouse by delta. Stock
+// never goes below zero: ErrInsufficientStock is returned instead.
+func addWarehouseStock(tx *sqlx.Tx, warehouseID, productID uuid.UUID, delta int) error {
+	if delta >= 0 {
+		query := `
+			INSERT INTO warehouse_stock (warehouse_id, product_id, quantity)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (warehouse_id, product_id) DO UPDATE SET quantity = warehouse_stock.quantity + EXCLUDED.quantity
+		`
+		_, err := tx.Exec(query, warehouseID, productID, delta)
+		return err
+	}
+
+	query := `
+		UPDATE warehouse_stock SET quantity = quantity + $3
+		WHERE warehouse_id = $1 AND product_id = $2 AND quantity + $3 >= 0
+	`
+	result, err := tx.Exec(query, warehouseID, productID, delta)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInsufficientStock
+	}
+
+	return nil
+}
+
+// syncProductStock sets the stock quantity of a product to the sum of its warehouse stock
+func syncProductStock(tx *sqlx.Tx, productID uuid.UUID, updatedAt time.Time) error {
+	query := `
+		UPDATE products
+		SET stock_quantity = (SELECT COALESCE(SUM(quantity), 0) FROM warehouse_stock WHERE product_id = $1),
+		    updated_at = $2
+		WHERE id = $1
+	`
+	_, err := tx.Exec(query, productID, updatedAt)
+	return err
+}
+
+// setProductStock brings the total stock of a product to quantity when it is set on
+// the product directly. The difference goes to the default warehouse, so the stock
+// of the other warehouses is left alone.
+func setProductStock(tx *sqlx.Tx, productID uuid.UUID, quantity int, updatedAt time.Time) error {
+	var current int
+	query := `SELECT COALESCE(SUM(quantity), 0) FROM warehouse_stock WHERE product_id = $1`
+	if err := tx.Get(&current, query, productID); err != nil {
+		return err
+	}
+	if quantity == current {
+		return nil
+	}
+
+	warehouseID, err := defaultWarehouseID(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := addWarehouseStock(tx, warehouseID, productID, quantity-current); err != nil {
+		if errors.Is(err, ErrInsufficientStock) {
+			return ErrStockAllocated
+		}
+		return err
+	}
+
+	return syncProductStock(tx, productID, updatedAt)
+}
+
+// recordMovement inserts an inventory movement
+func recordMovement(tx *sqlx.Tx, productID, warehouseID uuid.UUID, quantity int, movementType string, referenceID *uuid.UUID, notes string, createdBy *uuid.UUID, createdAt time.Time) error {
+	query := `
+		INSERT INTO inventory_movements (id, product_id, warehouse_id, quantity, movement_type, reference_id, notes, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := tx.Exec(query, uuid.New(), productID, warehouseID, quantity, movementType, referenceID, notes, createdBy, createdAt)
+	return err
+}
+
+// clearDefault takes the default flag away from the other warehouses
+func clearDefault(tx *sqlx.Tx, warehouseID uuid.UUID) error {
+	_, err := tx.Exec(`UPDATE warehouses SET is_default = FALSE WHERE is_default AND id <> $1`, warehouseID)
+	return err
+}
+
+func (r *warehouseRepository) Create(warehouse *model.Warehouse) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if warehouse.IsDefault {
+		if err := clearDefault(tx, warehouse.ID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO warehouses (id, code, name, address, is_active, is_default, created_at, updated_at, is_deleted)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err = tx.Exec(
+		query,
+		warehouse.ID,
+		warehouse.Code,
+		warehouse.Name,
+		warehouse.Address,
+		warehouse.IsActive,
+		warehouse.IsDefault,
+		warehouse.CreatedAt,
+		warehouse.UpdatedAt,
+		false,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *warehouseRepository) GetByID(id uuid.UUID) (*model.Warehouse, error) {
+	var warehouse model.Warehouse
+
+	query := `SELECT * FROM warehouses WHERE id = $1 AND is_deleted = FALSE`
+	if err := r.db.Get(&warehouse, query, id); err != nil {
+		return nil, err
+	}
+
+	return &warehouse, nil
+}
+
+func (r *warehouseRepository) GetDefault() (*model.Warehouse, error) {
+	var warehouse model.Warehouse
+
+	query := `SELECT * FROM warehouses WHERE is_default AND is_deleted = FALSE`
+	if err := r.db.Get(&warehouse, query); err != nil {
+		return nil, err
+	}
+
+	return &warehouse, nil
+}
+
+// CodeExists checks whether another warehouse uses the code
+func (r *warehouseRepository) CodeExists(code string, excludeID uuid.UUID) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM warehouses WHERE code = $1 AND id <> $2)`
+	err := r.db.Get(&exists, query, code, excludeID)
+	return exists, err
+}
+
+// List lists the warehouses, the default warehouse first
+func (r *warehouseRepository) List() ([]model.Warehouse, error) {
+	warehouses := []model.Warehouse{}
+
+	query := `SELECT * FROM warehouses WHERE is_deleted = FALSE ORDER BY is_default DESC, code ASC`
+	if err := r.db.Select(&warehouses, query); err != nil {
+		return nil, err
+	}
+
+	return warehouses, nil
+}
+
+// Update updates a warehouse. The default flag can only be moved to another
+// warehouse, by making that one the default.
+func (r *warehouseRepository) Update(warehouse *model.Warehouse) error {
+	warehouse.UpdatedAt = time.Now()
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if warehouse.IsDefault {
+		if err := clearDefault(tx, warehouse.ID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		UPDATE warehouses
+		SET code = $1, name = $2, address = $3, is_active = $4, is_default = is_default OR $5, updated_at = $6
+		WHERE id = $7
+	`
+
+	_, err = tx.Exec(
+		query,
+		warehouse.Code,
+		warehouse.Name,
+		warehouse.Address,
+		warehouse.IsActive,
+		warehouse.IsDefault,
+		warehouse.UpdatedAt,
+		warehouse.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete soft deletes an empty warehouse, keeping its movement history
+func (r *warehouseRepository) Delete(id uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var isDefault bool
+	if err := tx.Get(&isDefault, `SELECT is_default FROM warehouses WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return err
+	}
+	if isDefault {
+		return ErrWarehouseIsDefault
+	}
+
+	var stock int
+	if err := tx.Get(&stock, `SELECT COALESCE(SUM(quantity), 0) FROM warehouse_stock WHERE warehouse_id = $1`, id); err != nil {
+		return err
+	}
+	if stock > 0 {
+		return ErrWarehouseHasStock
+	}
+
+	if _, err := tx.Exec(`DELETE FROM warehouse_stock WHERE warehouse_id = $1`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE warehouses SET is_deleted = TRUE, is_active = FALSE, updated_at = $1 WHERE id = $2`, time.Now(), id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+const warehouseStockQuery = `
+	SELECT ws.warehouse_id, w.code AS warehouse_code, w.name AS warehouse_name,
+	       ws.product_id, p.sku AS product_sku, p.name AS product_name,
+	       ws.quantity, ws.updated_at
+	FROM warehouse_stock ws
+	JOIN warehouses w ON w.id = ws.warehouse_id
+	JOIN products p ON p.id = ws.product_id AND p.is_deleted = FALSE
+`
+
+// GetStock lists the stock levels held in a warehouse
+func (r *warehouseRepository) GetStock(warehouseID uuid.UUID, offset, limit int, search string) ([]model.WarehouseStock, int, error) {
+	stock := []model.WarehouseStock{}
+	var total int
+
+	where := ` WHERE ws.warehouse_id = $1 AND ws.quantity > 0 AND ($2 = '' OR p.sku ILIKE '%' || $2 || '%' OR p.name ILIKE '%' || $2 || '%')`
+
+	countQuery := `SELECT COUNT(*) FROM warehouse_stock ws JOIN products p ON p.id = ws.product_id AND p.is_deleted = FALSE` + where
+	if err := r.db.Get(&total, countQuery, warehouseID, search); err != nil {
+		return nil, 0, err
+	}
+
+	query := warehouseStockQuery + where + ` ORDER BY p.sku ASC LIMIT $3 OFFSET $4`
+	if err := r.db.Select(&stock, query, warehouseID, search, limit, offset); err != nil {
+		return nil, 0, err
+	}
+
+	return stock, total, nil
+}
+
+// GetProductStock lists the stock levels of a product per warehouse
+func (r *warehouseRepository) GetProductStock(productID uuid.UUID) ([]model.WarehouseStock, error) {
+	stock := []model.WarehouseStock{}
+
+	query := warehouseStockQuery + ` WHERE ws.product_id = $1 AND w.is_deleted = FALSE ORDER BY w.is_default DESC, w.code ASC`
+	if err := r.db.Select(&stock, query, productID); err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+// SetStock sets the stock level of a product in a warehouse, recording the
+// difference as an adjustment movement
+func (r *warehouseRepository) SetStock(warehouseID, productID uuid.UUID, quantity int, notes string, userID *uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current int
+	query := `SELECT quantity FROM warehouse_stock WHERE warehouse_id = $1 AND product_id = $2 FOR UPDATE`
+	if err := tx.Get(&current, query, warehouseID, productID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	delta := quantity - current
+	if delta == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if err := addWarehouseStock(tx, warehouseID, productID, delta); err != nil {
+		return err
+	}
+	if err := recordMovement(tx, productID, warehouseID, delta, model.MovementAdjustment, nil, notes, userID, now); err != nil {
+		return err
+	}
+	if err := syncProductStock(tx, productID, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Transfer moves stock of a product between two warehouses. The transfer is recorded
+// as a transfer_out and a transfer_in movement sharing the transfer ID as reference.
+// The total stock of the product does not change.
+func (r *warehouseRepository) Transfer(transfer *model.StockTransfer, notes string, userID *uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := addWarehouseStock(tx, transfer.FromWarehouseID, transfer.ProductID, -transfer.Quantity); err != nil {
+		return err
+	}
+	if err := addWarehouseStock(tx, transfer.ToWarehouseID, transfer.ProductID, transfer.Quantity); err != nil {
+		return err
+	}
+
+	err = recordMovement(tx, transfer.ProductID, transfer.FromWarehouseID, -transfer.Quantity,
+		model.MovementTransferOut, &transfer.ID, notes, userID, transfer.CreatedAt)
+	if err != nil {
+		return err
+	}
+	err = recordMovement(tx, transfer.ProductID, transfer.ToWarehouseID, transfer.Quantity,
+		model.MovementTransferIn, &transfer.ID, notes, userID, transfer.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -185,12 +185,11 @@ func (r *wishlistRepository) GetDashboardStats(userID uuid.UUID) (*model.Dashboa
 	// Get top rated products (limit to 5)
 	topProductsQuery := `
 		SELECT p.id, p.name, p.description, p.price, p.stock_quantity, p.status, 
-		       AVG(r.rating) as avg_rating, COUNT(r.id) as review_count
+		       rs.average_rating::float8 as avg_rating, rs.review_count
 		FROM products p
-		JOIN reviews r ON p.id = r.product_id
-		WHERE r.is_deleted = FALSE
-		GROUP BY p.id
-		ORDER BY avg_rating DESC, review_count DESC
+		JOIN product_rating_summaries rs ON p.id = rs.product_id
+		WHERE rs.review_count > 0
+		ORDER BY rs.average_rating DESC, rs.review_count DESC
 		LIMIT 5
 	`
 
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/config"
+	"golang-test1/platform/database"
+	"golang-test1/platform/logger"
+	"sync"
+	"time"
+)
+
+// PublishScheduler periodically publishes the products and drafts scheduled for publication
+type PublishScheduler struct {
+	interval time.Duration
+	repo     repo.PublishingRepository
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+var publishScheduler *PublishScheduler
+
+// StartPublishScheduler starts the default publish scheduler using the default DB and configuration.
+// Due publications are handled right away and then on every interval.
+func StartPublishScheduler() {
+	cfg := config.WorkerCfg()
+	if cfg.PublishSchedulerInterval <= 0 {
+		return
+	}
+
+	publishScheduler = &PublishScheduler{
+		interval: cfg.PublishSchedulerInterval,
+		repo:     repo.NewPublishingRepository(database.GetDB()),
+		stop:     make(chan struct{}),
+	}
+
+	publishScheduler.wg.Add(1)
+	go publishScheduler.run()
+}
+
+// StopPublishScheduler stops the default publish scheduler
+func StopPublishScheduler() {
+	if publishScheduler == nil {
+		return
+	}
+	close(publishScheduler.stop)
+	publishScheduler.wg.Wait()
+	publishScheduler = nil
+}
+
+func (s *PublishScheduler) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.publishDue()
+	for {
+		select {
+		case <-ticker.C:
+			s.publishDue()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *PublishScheduler) publishDue() {
+	published, err := s.repo.PublishDue(time.Now())
+	if err != nil {
+		logger.GetLogger().Errorf("failed to publish scheduled products: %v", err)
+		return
+	}
+	if published > 0 {
+		logger.GetLogger().Infof("%d scheduled products published", published)
+	}
+}
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/config"
+	"golang-test1/platform/database"
+	"golang-test1/platform/logger"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type productView struct {
+	userID    uuid.UUID
+	productID uuid.UUID
+	viewedAt  time.Time
+}
+
+// ViewRecorder writes product views in the background, so recording a view
+// never slows down the product read that triggered it.
+type ViewRecorder struct {
+	views chan productView
+	limit int
+	repo  repo.RecentlyViewedRepository
+	wg    sync.WaitGroup
+}
+
+var viewRecorder *ViewRecorder
+
+// StartViewRecorder starts the default view recorder using the default DB and configuration
+func StartViewRecorder() {
+	cfg := config.WorkerCfg()
+	viewRecorder = &ViewRecorder{
+		views: make(chan productView, cfg.RecentlyViewedBuffer),
+		limit: cfg.RecentlyViewedLimit,
+		repo:  repo.NewRecentlyViewedRepository(database.GetDB()),
+	}
+
+	viewRecorder.wg.Add(1)
+	go viewRecorder.run()
+}
+
+// StopViewRecorder stops the default view recorder after the queued views are written
+func StopViewRecorder() {
+	if viewRecorder == nil {
+		return
+	}
+	close(viewRecorder.views)
+	viewRecorder.wg.Wait()
+	viewRecorder = nil
+}
+
+// RecordView queues a product view of a user. The view is dropped when the
+// recorder is not running or its queue is full.
+func RecordView(userID, productID uuid.UUID) {
+	if viewRecorder == nil {
+		return
+	}
+
+	select {
+	case viewRecorder.views <- productView{userID: userID, productID: productID, viewedAt: time.Now()}:
+	default:
+		logger.GetLogger().Warnf("recently viewed queue is full, dropping view of product %s", productID)
+	}
+}
+
+func (r *ViewRecorder) run() {
+	defer r.wg.Done()
+
+	for view := range r.views {
+		if err := r.repo.Record(view.userID, view.productID, view.viewedAt, r.limit); err != nil {
+			logger.GetLogger().Errorf("failed to record view of product %s: %v", view.productID, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/config"
+	"golang-test1/platform/database"
+	"golang-test1/platform/logger"
+	"sync"
+	"time"
+)
+
+// RecommendationWorker periodically recomputes the product similarity table
+type RecommendationWorker struct {
+	interval   time.Duration
+	perProduct int
+	repo       repo.RecommendationRepository
+	stop       chan struct{}
+	wg         sync.WaitGroup
+}
+
+var recommendationWorker *RecommendationWorker
+
+// StartRecommendationWorker starts the default recommendation worker using the default DB and configuration.
+// The similarity table is computed right away and then on every interval.
+func StartRecommendationWorker() {
+	cfg := config.WorkerCfg()
+	if cfg.RecommendationsInterval <= 0 {
+		return
+	}
+
+	recommendationWorker = &RecommendationWorker{
+		interval:   cfg.RecommendationsInterval,
+		perProduct: cfg.RecommendationsPerProduct,
+		repo:       repo.NewRecommendationRepository(database.GetDB()),
+		stop:       make(chan struct{}),
+	}
+
+	recommendationWorker.wg.Add(1)
+	go recommendationWorker.run()
+}
+
+// StopRecommendationWorker stops the default recommendation worker
+func StopRecommendationWorker() {
+	if recommendationWorker == nil {
+		return
+	}
+	close(recommendationWorker.stop)
+	recommendationWorker.wg.Wait()
+	recommendationWorker = nil
+}
+
+func (w *RecommendationWorker) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.recompute()
+	for {
+		select {
+		case <-ticker.C:
+			w.recompute()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+func (w *RecommendationWorker) recompute() {
+	start := time.Now()
+	if err := w.repo.Recompute(start, w.perProduct); err != nil {
+		logger.GetLogger().Errorf("failed to recompute product similarities: %v", err)
+		return
+	}
+	logger.GetLogger().Infof("product similarities recomputed in %s", time.Since(start))
+}
@@ -0,0 +1,137 @@
+package worker
+
+import (
+	"fmt"
+	"golang-test1/app/model"
+	repo "golang-test1/app/repository"
+	"golang-test1/pkg/config"
+	"golang-test1/platform/database"
+	"golang-test1/platform/logger"
+	"golang-test1/platform/notification"
+	"sync"
+	"time"
+)
+
+// savedSearchPreviewSize is the number of new products listed in a notification
+const savedSearchPreviewSize = 5
+
+// SavedSearchWorker periodically notifies users about new products matching their saved searches
+type SavedSearchWorker struct {
+	interval    time.Duration
+	repo        repo.SavedSearchRepository
+	productRepo repo.ProductRepository
+	notifier    notification.Notifier
+	stop        chan struct{}
+	wg          sync.WaitGroup
+}
+
+var savedSearchWorker *SavedSearchWorker
+
+// StartSavedSearchWorker starts the default saved search worker using the default DB, notifier and configuration
+func StartSavedSearchWorker() {
+	cfg := config.WorkerCfg()
+	if cfg.SavedSearchInterval <= 0 {
+		return
+	}
+
+	savedSearchWorker = &SavedSearchWorker{
+		interval:    cfg.SavedSearchInterval,
+		repo:        repo.NewSavedSearchRepository(database.GetDB()),
+		productRepo: repo.NewProductRepository(database.GetDB()),
+		notifier:    notification.GetNotifier(),
+		stop:        make(chan struct{}),
+	}
+
+	savedSearchWorker.wg.Add(1)
+	go savedSearchWorker.run()
+}
+
+// StopSavedSearchWorker stops the default saved search worker
+func StopSavedSearchWorker() {
+	if savedSearchWorker == nil {
+		return
+	}
+	close(savedSearchWorker.stop)
+	savedSearchWorker.wg.Wait()
+	savedSearchWorker = nil
+}
+
+func (w *SavedSearchWorker) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.checkAll()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+func (w *SavedSearchWorker) checkAll() {
+	savedSearches, err := w.repo.GetNotifiable()
+	if err != nil {
+		logger.GetLogger().Errorf("failed to load saved searches: %v", err)
+		return
+	}
+
+	for _, savedSearch := range savedSearches {
+		if err := w.check(savedSearch); err != nil {
+			logger.GetLogger().Errorf("failed to check saved search %s: %v", savedSearch.ID, err)
+		}
+	}
+}
+
+// check notifies the user about the products matching the saved search that were
+// published since the last check
+func (w *SavedSearchWorker) check(savedSearch model.SavedSearch) error {
+	checkedAt := time.Now()
+	// published_after is inclusive, products published at the previous check time were already seen
+	publishedAfter := savedSearch.LastCheckedAt.Add(time.Microsecond)
+
+	// Only announce products customers can buy unless the search asks for a status
+	filters := savedSearch.Filters
+	status := filters.Status
+	if status == "" {
+		status = "active"
+	}
+
+	products, total, err := w.productRepo.ListWithFilters(
+		0, savedSearchPreviewSize, filters.Search, filters.CategoryID, filters.IncludeDescendants, status, model.PublishStatusPublished,
+		filters.MinPrice, filters.MaxPrice, filters.MinStock, filters.MaxStock, nil,
+		nil, nil, &publishedAfter, &checkedAt, false, "created_at", "desc",
+	)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 {
+		productIDs := make([]string, len(products))
+		body := fmt.Sprintf("%d new products match your saved search:", total)
+		for i, product := range products {
+			productIDs[i] = product.ID.String()
+			body += "\n- " + product.Name
+		}
+
+		err := w.notifier.Notify(notification.Notification{
+			UserID:  savedSearch.UserID,
+			Type:    "saved_search_match",
+			Subject: fmt.Sprintf("New products for \"%s\"", savedSearch.Name),
+			Body:    body,
+			Data: map[string]any{
+				"saved_search_id": savedSearch.ID,
+				"total":           total,
+				"product_ids":     productIDs,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.repo.MarkChecked(savedSearch.ID, checkedAt)
+}
@@ -3,11 +3,15 @@ package server
 import (
 	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"golang-test1/app/model"
+	"golang-test1/app/worker"
 	"golang-test1/pkg/config"
 	"golang-test1/pkg/middleware"
 	"golang-test1/pkg/route"
 	"golang-test1/platform/database"
 	"golang-test1/platform/logger"
+	"golang-test1/platform/notification"
+	"golang-test1/platform/storage"
 	"os"
 	"os/signal"
 	"syscall"
@@ -24,6 +28,19 @@ func Serve() {
 		logr.Panicf("failed database setup. error: %v", err)
 	}
 
+	// set up notification delivery
+	notification.SetUpNotifier()
+
+	// set up file storage
+	storage.SetUpStorage()
+	model.ReviewPhotoURL = storage.GetStorage().URL
+
+	// start background workers
+	worker.StartViewRecorder()
+	worker.StartRecommendationWorker()
+	worker.StartSavedSearchWorker()
+	worker.StartPublishScheduler()
+
 	// Define Fiber config & app.
 	fiberCfg := config.FiberConfig()
 	app := fiber.New(fiberCfg)
@@ -34,6 +51,7 @@ func Serve() {
 	// Routes.
 	route.GeneralRoute(app)
 	route.SwaggerRoute(app)
+	route.UploadRoute(app)
 	route.PublicRoutes(app)
 	route.PrivateRoutes(app)
 	route.NotFoundRoute(app)
@@ -56,4 +74,10 @@ func Serve() {
 		logr.Errorf("Oops... server is not running! error: %v", err)
 	}
 
+	// flush background workers once no request is in flight anymore
+	worker.StopViewRecorder()
+	worker.StopRecommendationWorker()
+	worker.StopSavedSearchWorker()
+	worker.StopPublishScheduler()
+
 }
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+)
+
+// Cache holds the HTTP caching configuration
+type Cache struct {
+	ProductsControl   string
+	CategoriesControl string
+}
+
+var cache = &Cache{}
+
+// CacheCfg returns the default Cache configuration
+func CacheCfg() *Cache {
+	return cache
+}
+
+// LoadCacheCfg loads Cache configuration
+func LoadCacheCfg() {
+	cache.ProductsControl = getEnvDefault("CACHE_CONTROL_PRODUCTS", "public, max-age=60, s-maxage=300")
+	cache.CategoriesControl = getEnvDefault("CACHE_CONTROL_CATEGORIES", "public, max-age=300, s-maxage=3600")
+}
+
+// getEnvDefault returns the value of the environment variable or the given default when it is unset
+func getEnvDefault(key, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return defaultValue
+}
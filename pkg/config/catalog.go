@@ -0,0 +1,22 @@
+package config
+
+// Catalog holds the product listing configuration
+type Catalog struct {
+	// MerchandisedFallbackSort and MerchandisedFallbackOrder order the unpinned products
+	// of a merchandised category listing, after the pinned ones
+	MerchandisedFallbackSort  string
+	MerchandisedFallbackOrder string
+}
+
+var catalog = &Catalog{}
+
+// CatalogCfg returns the default Catalog configuration
+func CatalogCfg() *Catalog {
+	return catalog
+}
+
+// LoadCatalogCfg loads Catalog configuration
+func LoadCatalogCfg() {
+	catalog.MerchandisedFallbackSort = getEnvDefault("MERCHANDISED_FALLBACK_SORT", "created_at")
+	catalog.MerchandisedFallbackOrder = getEnvDefault("MERCHANDISED_FALLBACK_ORDER", "desc")
+}
@@ -18,6 +18,11 @@ func LoadAllConfigs(envFile string) {
 
 	LoadApp()
 	LoadDBCfg()
+	LoadCacheCfg()
+	LoadCatalogCfg()
+	LoadWorkerCfg()
+	LoadNotificationCfg()
+	LoadStorageCfg()
 }
 
 // FiberConfig func for configuration Fiber app.
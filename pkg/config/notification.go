@@ -0,0 +1,19 @@
+package config
+
+// Notification holds the notification delivery configuration
+type Notification struct {
+	// Driver is "outbox" to store notifications in the database for a relay, or "log" to only log them
+	Driver string
+}
+
+var notification = &Notification{}
+
+// NotificationCfg returns the default Notification configuration
+func NotificationCfg() *Notification {
+	return notification
+}
+
+// LoadNotificationCfg loads Notification configuration
+func LoadNotificationCfg() {
+	notification.Driver = getEnvDefault("NOTIFICATION_DRIVER", "outbox")
+}
@@ -0,0 +1,57 @@
+package config
+
+import "log"
+
+// Storage holds the file storage configuration
+type Storage struct {
+	// Driver selects where uploaded files are kept, "local" for the local filesystem
+	Driver string
+	// LocalPath is the directory of the local driver, served under PublicURL
+	LocalPath string
+	PublicURL string
+
+	// ReviewPhotoMaxCount and ReviewPhotoMaxSize limit the photos attached to a review
+	ReviewPhotoMaxCount int
+	ReviewPhotoMaxSize  int64 // bytes
+}
+
+// Upper bounds of the review photo limits, keeping the upload body limit reasonable
+const (
+	maxReviewPhotoCount  = 20
+	maxReviewPhotoSizeMB = 20
+)
+
+var storage = &Storage{}
+
+// StorageCfg returns the default Storage configuration
+func StorageCfg() *Storage {
+	return storage
+}
+
+// ReviewPhotoUploadLimit is the request body limit of a review photo upload, a full set
+// of photos plus room for the multipart framing
+func (s *Storage) ReviewPhotoUploadLimit() int {
+	limit := s.ReviewPhotoMaxSize*int64(s.ReviewPhotoMaxCount) + 1<<20
+	if limit < 0 || limit > int64(maxReviewPhotoCount*maxReviewPhotoSizeMB+1)<<20 {
+		log.Fatalf("invalid review photo upload limit %d bytes", limit)
+	}
+	return int(limit)
+}
+
+// LoadStorageCfg loads Storage configuration
+func LoadStorageCfg() {
+	storage.Driver = getEnvDefault("STORAGE_DRIVER", "local")
+	storage.LocalPath = getEnvDefault("STORAGE_LOCAL_PATH", "./uploads")
+	storage.PublicURL = getEnvDefault("STORAGE_PUBLIC_URL", "/uploads")
+
+	storage.ReviewPhotoMaxCount = getEnvInt("REVIEW_PHOTO_MAX_COUNT", 5)
+	if storage.ReviewPhotoMaxCount < 1 || storage.ReviewPhotoMaxCount > maxReviewPhotoCount {
+		log.Fatalf("REVIEW_PHOTO_MAX_COUNT must be between 1 and %d, got %d", maxReviewPhotoCount, storage.ReviewPhotoMaxCount)
+	}
+
+	sizeMB := getEnvInt("REVIEW_PHOTO_MAX_SIZE_MB", 5)
+	if sizeMB < 1 || sizeMB > maxReviewPhotoSizeMB {
+		log.Fatalf("REVIEW_PHOTO_MAX_SIZE_MB must be between 1 and %d, got %d", maxReviewPhotoSizeMB, sizeMB)
+	}
+	storage.ReviewPhotoMaxSize = int64(sizeMB) << 20
+}
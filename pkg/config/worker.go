@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Worker holds the background worker configuration
+type Worker struct {
+	RecentlyViewedLimit  int
+	RecentlyViewedBuffer int
+
+	RecommendationsInterval   time.Duration
+	RecommendationsPerProduct int
+
+	SavedSearchInterval time.Duration
+
+	PublishSchedulerInterval time.Duration
+}
+
+var worker = &Worker{}
+
+// WorkerCfg returns the default Worker configuration
+func WorkerCfg() *Worker {
+	return worker
+}
+
+// LoadWorkerCfg loads Worker configuration
+func LoadWorkerCfg() {
+	worker.RecentlyViewedLimit = getEnvInt("RECENTLY_VIEWED_LIMIT", 20)
+	worker.RecentlyViewedBuffer = getEnvInt("RECENTLY_VIEWED_BUFFER", 1000)
+
+	worker.RecommendationsInterval = time.Duration(getEnvInt("RECOMMENDATIONS_INTERVAL_MINUTES", 60)) * time.Minute
+	worker.RecommendationsPerProduct = getEnvInt("RECOMMENDATIONS_PER_PRODUCT", 20)
+
+	worker.SavedSearchInterval = time.Duration(getEnvInt("SAVED_SEARCH_INTERVAL_MINUTES", 15)) * time.Minute
+
+	worker.PublishSchedulerInterval = time.Duration(getEnvInt("PUBLISH_SCHEDULER_INTERVAL_SECONDS", 60)) * time.Second
+}
+
+// getEnvInt returns the integer value of the environment variable or the given default when it is unset or invalid
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
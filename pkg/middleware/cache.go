@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// CacheControl func sets the given Cache-Control policy on successful GET and HEAD responses,
+// so shared caches (CDN) are allowed to store the public catalogue. A policy set by the
+// handler itself is kept.
+func CacheControl(policy string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if policy == "" || (c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead) {
+			return c.Next()
+		}
+
+		err := c.Next()
+
+		// Never let caches store error responses
+		status := c.Response().StatusCode()
+		if status != fiber.StatusOK && status != fiber.StatusNotModified {
+			return err
+		}
+
+		// Responses to authenticated requests may be personalised
+		if len(c.Response().Header.Peek(fiber.HeaderCacheControl)) == 0 {
+			if c.Get(fiber.HeaderAuthorization) != "" {
+				c.Set(fiber.HeaderCacheControl, "private, no-cache")
+			} else {
+				c.Set(fiber.HeaderCacheControl, policy)
+			}
+		}
+		c.Vary(fiber.HeaderAuthorization)
+
+		return err
+	}
+}
@@ -1,10 +1,14 @@
 package middleware
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/valyala/fasthttp"
 )
 
 func FiberMiddleware(a *fiber.App) {
@@ -16,6 +20,23 @@ func FiberMiddleware(a *fiber.App) {
 	)
 }
 
+// RouteBodyLimit sets the request body limit of the requests whose method and path match,
+// in place of the app body limit. The limit is picked from the request headers, before
+// the body is read.
+func RouteBodyLimit(a *fiber.App, method string, path *regexp.Regexp, limit int) {
+	next := a.Server().HeaderReceived
+	a.Server().HeaderReceived = func(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+		uri, _, _ := strings.Cut(string(header.RequestURI()), "?")
+		if string(header.Method()) == method && path.MatchString(uri) {
+			return fasthttp.RequestConfig{MaxRequestBodySize: limit}
+		}
+		if next != nil {
+			return next(header)
+		}
+		return fasthttp.RequestConfig{}
+	}
+}
+
 func IsAdmin(c *fiber.Ctx) error {
 	user := c.Locals("user").(*jwt.Token)
 	claims := user.Claims.(jwt.MapClaims)
@@ -28,3 +49,17 @@ func IsAdmin(c *fiber.Ctx) error {
 
 	return c.Next()
 }
+
+// IsEditor lets editors and admins through, the roles that may prepare products for publication
+func IsEditor(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	role, ok := claims["role"]
+	if !ok || (role != "editor" && role != "admin") {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"msg": "Forbidden",
+		})
+	}
+
+	return c.Next()
+}
@@ -25,6 +25,32 @@ func JWTProtected() func(*fiber.Ctx) error {
 	return jwtware.New(jwtwareConfig)
 }
 
+// JWTOptional func for routes that are public but behave per user when a token is sent.
+// Requests without a valid token are let through anonymously.
+func JWTOptional() func(*fiber.Ctx) error {
+	jwtwareConfig := jwtware.Config{
+		SigningKey: []byte(config.AppCfg().JWTSecretKey),
+		ContextKey: "user",
+		Filter: func(c *fiber.Ctx) bool {
+			return c.Get(fiber.HeaderAuthorization) == ""
+		},
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return c.Next()
+		},
+		SuccessHandler: func(c *fiber.Ctx) error {
+			user := c.Locals("user").(*jwt.Token)
+			claims := user.Claims.(jwt.MapClaims)
+			if exp, ok := claims["exp"].(float64); !ok || time.Now().Unix() > int64(exp) {
+				c.Locals("user", nil)
+			}
+			return c.Next()
+		},
+		SigningMethod: "HS256",
+	}
+
+	return jwtware.New(jwtwareConfig)
+}
+
 func verifyTokenExpiration(c *fiber.Ctx) error {
 	user := c.Locals("user").(*jwt.Token)
 	claims := user.Claims.(jwt.MapClaims)
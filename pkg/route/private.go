@@ -2,7 +2,9 @@ package route
 
 import (
 	"golang-test1/app/controller"
+	"golang-test1/pkg/config"
 	"golang-test1/pkg/middleware"
+	"regexp"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -24,16 +26,33 @@ func PrivateRoutes(a *fiber.App) {
 
 	// Category route group - Admin routes for managing categories
 	categoryAdminRoute := a.Group("/api/v1/categories", middleware.JWTProtected(), middleware.IsAdmin)
-	categoryAdminRoute.Post("/", controller.CreateCategory)      // Create a new category
-	categoryAdminRoute.Put("/:id", controller.UpdateCategory)    // Update a category
-	categoryAdminRoute.Delete("/:id", controller.DeleteCategory) // Delete a category
+	categoryAdminRoute.Post("/", controller.CreateCategory)                           // Create a new category
+	categoryAdminRoute.Put("/reorder", controller.ReorderCategories)                  // Reorder sibling categories
+	categoryAdminRoute.Put("/:id", controller.UpdateCategory)                         // Update a category
+	categoryAdminRoute.Put("/:id/move", controller.MoveCategory)                      // Move a category in the tree
+	categoryAdminRoute.Post("/:id/merge", controller.MergeCategory)                   // Merge a category into another one
+	categoryAdminRoute.Get("/:id/merchandising", controller.GetCategoryMerchandising) // Get the pinned products of a category
+	categoryAdminRoute.Put("/:id/merchandising", controller.SetCategoryMerchandising) // Pin products within a category
+	categoryAdminRoute.Delete("/:id", controller.DeleteCategory)                      // Delete a category
 
 	// Product routes
+	// Editor product routes - editors and admins prepare products, the workflow checks who may publish
+	productEditorRoute := a.Group("/api/v1/products", middleware.JWTProtected(), middleware.IsEditor)
+	productEditorRoute.Post("/", controller.CreateProduct)                                  // Create a new product as a draft
+	productEditorRoute.Put("/:id", controller.UpdateProduct)                                // Update a product or its pending draft
+	productEditorRoute.Post("/:id/clone", controller.CloneProduct)                          // Copy a product into a new draft
+	productEditorRoute.Get("/:id/draft", controller.GetProductDraft)                        // Get the pending draft of a product
+	productEditorRoute.Put("/:id/publish-status", controller.UpdatePublishStatus)           // Move a product through the publishing workflow
+	productEditorRoute.Get("/barcode/:code", controller.GetProductByBarcode)                // Look up a product by scanned barcode
+	productEditorRoute.Get("/:id/barcodes", controller.GetProductBarcodes)                  // Get the barcodes of a product
+	productEditorRoute.Post("/:id/barcodes", controller.AddProductBarcode)                  // Add a barcode to a product
+	productEditorRoute.Delete("/:id/barcodes/:barcode_id", controller.DeleteProductBarcode) // Remove a barcode from a product
+
 	// Admin product routes - require admin privileges
 	productAdminRoute := a.Group("/api/v1/products", middleware.JWTProtected(), middleware.IsAdmin)
-	productAdminRoute.Post("/", controller.CreateProduct)      // Create a new product
-	productAdminRoute.Put("/:id", controller.UpdateProduct)    // Update a product
-	productAdminRoute.Delete("/:id", controller.DeleteProduct) // Delete a product
+	productAdminRoute.Delete("/:id", controller.DeleteProduct)              // Delete a product
+	productAdminRoute.Get("/:id/suppliers", controller.GetProductSuppliers) // Get the suppliers of a product
+	productAdminRoute.Get("/:id/stock", controller.GetProductStock)         // Get the stock of a product per warehouse
 
 	// Public product routes - accessible to all authenticated users
 	productRoute := a.Group("/api/v1/products", middleware.JWTProtected())
@@ -41,6 +60,14 @@ func PrivateRoutes(a *fiber.App) {
 	productRoute.Get("/:id", controller.GetProduct)                      // Get a product by ID
 	productRoute.Get("/:id/categories", controller.GetProductCategories) // Get product categories
 
+	// Search admin routes - synonyms and search analytics
+	searchAdminRoute := a.Group("/api/v1/search", middleware.JWTProtected(), middleware.IsAdmin)
+	searchAdminRoute.Get("/synonyms", controller.ListSearchSynonyms)         // List synonym groups
+	searchAdminRoute.Post("/synonyms", controller.CreateSearchSynonym)       // Create a synonym group
+	searchAdminRoute.Put("/synonyms/:id", controller.UpdateSearchSynonym)    // Update a synonym group
+	searchAdminRoute.Delete("/synonyms/:id", controller.DeleteSearchSynonym) // Delete a synonym group
+	searchAdminRoute.Get("/report", controller.GetSearchReport)              // Get the search analytics report
+
 	// Wishlist routes - accessible to all authenticated users
 	wishlistRoute := a.Group("/api/v1/wishlist", middleware.JWTProtected())
 	wishlistRoute.Post("/", controller.AddToWishlist)                     // Add item to wishlist
@@ -48,6 +75,11 @@ func PrivateRoutes(a *fiber.App) {
 	wishlistRoute.Get("/", controller.GetWishlist)                        // Get user's wishlist
 	wishlistRoute.Get("/check/:product_id", controller.CheckWishlistItem) // Check if item is in wishlist
 
+	// Review moderation routes - registered before /:id, admins approve or reject reviews
+	reviewModerationRoute := a.Group("/api/v1/reviews/moderation", middleware.JWTProtected(), middleware.IsAdmin)
+	reviewModerationRoute.Get("/", controller.GetReviewModerationQueue) // List reviews to moderate
+	reviewModerationRoute.Put("/", controller.ModerateReviews)          // Approve or reject reviews in bulk
+
 	// Review routes - accessible to all authenticated users
 	reviewRoute := a.Group("/api/v1/reviews", middleware.JWTProtected())
 	reviewRoute.Post("/", controller.CreateReview)            // Create a new review
@@ -55,12 +87,93 @@ func PrivateRoutes(a *fiber.App) {
 	reviewRoute.Get("/my-reviews", controller.GetUserReviews) // Get all reviews by current user
 	reviewRoute.Get("/:id", controller.GetReview)             // Get a review by ID
 
-	reviewRoute.Put("/:id", controller.UpdateReview)    // Update a review
-	reviewRoute.Delete("/:id", controller.DeleteReview) // Delete a review
+	reviewRoute.Put("/:id", controller.UpdateReview)                          // Update a review
+	reviewRoute.Delete("/:id", controller.DeleteReview)                       // Delete a review
+	reviewRoute.Post("/:id/helpful", controller.VoteReview)                   // Vote on a review
+	reviewRoute.Delete("/:id/helpful", controller.UnvoteReview)               // Remove a vote on a review
+	reviewRoute.Post("/:id/photos", controller.UploadReviewPhotos)            // Upload photos to a review
+	reviewRoute.Delete("/:id/photos/:photo_id", controller.DeleteReviewPhoto) // Remove a photo from a review
+
+	// Photo uploads may be larger than other requests, up to a full set of photos
+	reviewPhotosPath := regexp.MustCompile(`(?i)^/api/v1/reviews/[^/]+/photos/?$`)
+	middleware.RouteBodyLimit(a, fiber.MethodPost, reviewPhotosPath, config.StorageCfg().ReviewPhotoUploadLimit())
 
 	// Product review routes
 	productRoute.Get("/:product_id/reviews", controller.GetProductReviews) // Get all reviews for a product
 
+	// Current user routes - personal data of the authenticated user
+	meRoute := a.Group("/api/v1/me", middleware.JWTProtected())
+	meRoute.Get("/recently-viewed", controller.GetRecentlyViewed)                   // Get recently viewed products
+	meRoute.Delete("/recently-viewed", controller.ClearRecentlyViewed)              // Clear recently viewed products
+	meRoute.Delete("/recently-viewed/:product_id", controller.RemoveRecentlyViewed) // Remove a recently viewed product
+	meRoute.Get("/recommendations", controller.GetMyRecommendations)                // Get personalised recommendations
+	meRoute.Get("/saved-searches", controller.GetSavedSearches)                     // Get saved searches
+	meRoute.Post("/saved-searches", controller.CreateSavedSearch)                   // Save a search
+	meRoute.Put("/saved-searches/:id", controller.UpdateSavedSearch)                // Update a saved search
+	meRoute.Delete("/saved-searches/:id", controller.DeleteSavedSearch)             // Delete a saved search
+
+	// Supplier routes - admin management of suppliers and the products they deliver
+	supplierRoute := a.Group("/api/v1/suppliers", middleware.JWTProtected(), middleware.IsAdmin)
+	supplierRoute.Get("/", controller.ListSuppliers)                                    // List suppliers
+	supplierRoute.Post("/", controller.CreateSupplier)                                  // Create a supplier
+	supplierRoute.Get("/:id", controller.GetSupplier)                                   // Get a supplier by ID
+	supplierRoute.Put("/:id", controller.UpdateSupplier)                                // Update a supplier
+	supplierRoute.Delete("/:id", controller.DeleteSupplier)                             // Delete a supplier
+	supplierRoute.Get("/:id/products", controller.GetSupplierProducts)                  // Get the products of a supplier
+	supplierRoute.Put("/:id/products/:product_id", controller.SaveProductSupplier)      // Link a product to a supplier
+	supplierRoute.Delete("/:id/products/:product_id", controller.DeleteProductSupplier) // Unlink a product from a supplier
+
+	// Purchase order routes - admin ordering and goods receipts
+	purchaseOrderRoute := a.Group("/api/v1/purchase-orders", middleware.JWTProtected(), middleware.IsAdmin)
+	purchaseOrderRoute.Get("/", controller.ListPurchaseOrders)                // List purchase orders
+	purchaseOrderRoute.Post("/", controller.CreatePurchaseOrder)              // Create a draft purchase order
+	purchaseOrderRoute.Get("/:id", controller.GetPurchaseOrder)               // Get a purchase order by ID
+	purchaseOrderRoute.Put("/:id", controller.UpdatePurchaseOrder)            // Update a draft purchase order
+	purchaseOrderRoute.Delete("/:id", controller.DeletePurchaseOrder)         // Delete a draft purchase order
+	purchaseOrderRoute.Post("/:id/send", controller.SendPurchaseOrder)        // Mark a purchase order as sent
+	purchaseOrderRoute.Post("/:id/receipts", controller.ReceivePurchaseOrder) // Receive goods for a purchase order
+
+	// Warehouse routes - admin management of warehouses and their stock
+	warehouseRoute := a.Group("/api/v1/warehouses", middleware.JWTProtected(), middleware.IsAdmin)
+	warehouseRoute.Get("/", controller.ListWarehouses)                         // List warehouses
+	warehouseRoute.Post("/", controller.CreateWarehouse)                       // Create a warehouse
+	warehouseRoute.Post("/transfers", controller.TransferStock)                // Transfer stock between warehouses
+	warehouseRoute.Get("/:id", controller.GetWarehouse)                        // Get a warehouse by ID
+	warehouseRoute.Put("/:id", controller.UpdateWarehouse)                     // Update a warehouse
+	warehouseRoute.Delete("/:id", controller.DeleteWarehouse)                  // Delete a warehouse
+	warehouseRoute.Get("/:id/stock", controller.GetWarehouseStock)             // Get the stock held in a warehouse
+	warehouseRoute.Put("/:id/stock/:product_id", controller.SetWarehouseStock) // Set the stock of a product in a warehouse
+
+	// Stock count routes - staff count stock, admins approve the variances
+	stockCountRoute := a.Group("/api/v1/stock-counts", middleware.JWTProtected(), middleware.IsEditor)
+	stockCountRoute.Get("/", controller.ListStockCounts)                   // List stock counts
+	stockCountRoute.Post("/", controller.CreateStockCount)                 // Open a stock count
+	stockCountRoute.Get("/:id", controller.GetStockCount)                  // Get a stock count by ID
+	stockCountRoute.Put("/:id/lines", controller.RecordStockCounts)        // Record counted quantities
+	stockCountRoute.Post("/:id/submit", controller.SubmitStockCount)       // Submit a stock count for approval
+	stockCountRoute.Get("/:id/variance", controller.GetStockCountVariance) // Get the variance report
+
+	stockCountAdminRoute := a.Group("/api/v1/stock-counts", middleware.JWTProtected(), middleware.IsAdmin)
+	stockCountAdminRoute.Post("/:id/approve", controller.ApproveStockCount) // Approve and post the variances
+	stockCountAdminRoute.Post("/:id/cancel", controller.CancelStockCount)   // Cancel a stock count
+
+	// Collection routes - admin management of rule-based collections
+	collectionAdminRoute := a.Group("/api/v1/collections", middleware.JWTProtected(), middleware.IsAdmin)
+	collectionAdminRoute.Post("/", controller.CreateCollection)                                    // Create a collection
+	collectionAdminRoute.Post("/preview", controller.PreviewCollection)                            // Preview the products matched by rules
+	collectionAdminRoute.Get("/:id", controller.GetCollection)                                     // Get a collection by ID
+	collectionAdminRoute.Put("/:id", controller.UpdateCollection)                                  // Update a collection
+	collectionAdminRoute.Delete("/:id", controller.DeleteCollection)                               // Delete a collection
+	collectionAdminRoute.Get("/:id/overrides", controller.GetCollectionOverrides)                  // Get the manual overrides
+	collectionAdminRoute.Put("/:id/overrides/:product_id", controller.SetCollectionOverride)       // Include or exclude a product
+	collectionAdminRoute.Delete("/:id/overrides/:product_id", controller.DeleteCollectionOverride) // Remove the override of a product
+
+	// Report routes - admin reports
+	reportRoute := a.Group("/api/v1/reports", middleware.JWTProtected(), middleware.IsAdmin)
+	reportRoute.Get("/margins/products", controller.GetProductMarginReport)    // Get product margins
+	reportRoute.Get("/margins/categories", controller.GetCategoryMarginReport) // Get margins per category
+	reportRoute.Get("/stock", controller.GetStockReport)                       // Get stock levels per warehouse
+
 	dashboardRoutes := a.Group("/api/v1/dashboard", middleware.JWTProtected())
 	dashboardRoutes.Get("/stats", controller.GetDashboardStats)
 }
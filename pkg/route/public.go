@@ -2,6 +2,8 @@ package route
 
 import (
 	"golang-test1/app/controller"
+	"golang-test1/pkg/config"
+	"golang-test1/pkg/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -14,16 +16,39 @@ func PublicRoutes(a *fiber.App) {
 	route.Post("/login", controller.Login)
 	route.Post("/register", controller.CreateUser)
 
+	// Cache-Control policies for the public catalogue, so a CDN can cache it
+	categoryCache := middleware.CacheControl(config.CacheCfg().CategoriesControl)
+	productCache := middleware.CacheControl(config.CacheCfg().ProductsControl)
+
 	// Category route group - Public routes for viewing categories
 	categoryRoute := a.Group("/api/v1/categories")
-	categoryRoute.Get("/", controller.ListCategories)                           // Get all categories
+	categoryRoute.Get("/", categoryCache, controller.ListCategories)            // Get all categories
+	categoryRoute.Get("/tree", categoryCache, controller.GetCategoryTree)       // Get the nested category tree
 	categoryRoute.Get("/:id", controller.GetCategory)                           // Get a category by ID
 	categoryRoute.Get("/slug/:slug", controller.GetCategoryBySlug)              // Get a category by slug
 	categoryRoute.Get("/:id/product-count", controller.GetCategoryProductCount) // Get product count for a category
+	categoryRoute.Get("/:id/breadcrumbs", controller.GetCategoryBreadcrumbs)    // Get the ancestor chain of a category
 
 	// Public product routes - accessible without authentication
 	productPublicRoute := a.Group("/api/v1/products")
-	productPublicRoute.Get("/", controller.GetProducts)                        // List all products
-	productPublicRoute.Get("/:id", controller.GetProduct)                      // Get a product by ID
-	productPublicRoute.Get("/:id/categories", controller.GetProductCategories) // Get product categories
+	productPublicRoute.Get("/compare", controller.CompareProducts) // Compare products side by side
+	productPublicRoute.Post("/batch", controller.GetProductsBatch) // Get many products by ID or SKU
+
+	// Public product routes that behave per user when a token is sent
+	optionalAuth := middleware.JWTOptional()
+	productPublicRoute.Get("/", optionalAuth, productCache, controller.GetProducts)                                       // List all products
+	productPublicRoute.Get("/:id", optionalAuth, productCache, controller.GetProduct)                                     // Get a product by ID
+	productPublicRoute.Get("/:id/recommendations", optionalAuth, controller.GetProductRecommendations)                    // Get similar products
+	productPublicRoute.Get("/:id/categories", optionalAuth, controller.GetProductCategories)                              // Get product categories
+	productPublicRoute.Get("/:product_id/rating-summary", optionalAuth, productCache, controller.GetProductRatingSummary) // Get the rating summary of a product
+
+	// Collection route group - rule-based product collections
+	collectionRoute := a.Group("/api/v1/collections")
+	collectionRoute.Get("/", optionalAuth, controller.ListCollections)               // List collections
+	collectionRoute.Get("/slug/:slug", productCache, controller.GetCollectionBySlug) // Get a collection with its products
+
+	// Search route group - search-as-you-type suggestions and result clicks
+	searchRoute := a.Group("/api/v1/search")
+	searchRoute.Get("/suggest", productCache, controller.SearchSuggest)     // Get search suggestions
+	searchRoute.Post("/clicks", optionalAuth, controller.RecordSearchClick) // Record a click on a search result
 }
@@ -4,6 +4,7 @@ import (
 	swagger "github.com/arsmn/fiber-swagger/v2"
 	"github.com/gofiber/fiber/v2"
 	"golang-test1/platform/database"
+	"golang-test1/platform/storage"
 )
 
 func GeneralRoute(a *fiber.App) {
@@ -36,6 +37,13 @@ func SwaggerRoute(a *fiber.App) {
 	route.Get("*", swagger.Handler)
 }
 
+// UploadRoute serves uploaded files when they are kept on the local filesystem
+func UploadRoute(a *fiber.App) {
+	if local, ok := storage.GetStorage().(*storage.LocalStorage); ok {
+		a.Static(local.PublicURL(), local.Root())
+	}
+}
+
 func NotFoundRoute(a *fiber.App) {
 	a.Use(
 		func(c *fiber.Ctx) error {
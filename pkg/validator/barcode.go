@@ -0,0 +1,67 @@
+package validator
+
+// Barcode types, all GTIN family codes ending with a mod 10 check digit
+const (
+	BarcodeEAN8   = "ean8"
+	BarcodeUPCA   = "upca"
+	BarcodeEAN13  = "ean13"
+	BarcodeGTIN14 = "gtin14"
+)
+
+var barcodeLengths = map[string]int{
+	BarcodeEAN8:   8,
+	BarcodeUPCA:   12,
+	BarcodeEAN13:  13,
+	BarcodeGTIN14: 14,
+}
+
+// BarcodeType returns the barcode type matching the length of the code, or an
+// empty string when no type has that length.
+func BarcodeType(code string) string {
+	for barcodeType, length := range barcodeLengths {
+		if len(code) == length {
+			return barcodeType
+		}
+	}
+	return ""
+}
+
+// ValidBarcode checks that the code is made of digits, has the length of the
+// barcode type and ends with the right check digit.
+func ValidBarcode(code, barcodeType string) bool {
+	length, ok := barcodeLengths[barcodeType]
+	if !ok || len(code) != length {
+		return false
+	}
+
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return int(code[len(code)-1]-'0') == gtinCheckDigit(code[:len(code)-1])
+}
+
+// gtinCheckDigit computes the GS1 check digit: digits are weighted 3 and 1
+// alternately, starting with 3 at the rightmost digit.
+func gtinCheckDigit(digits string) int {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10 - sum%10) % 10
+}
+
+// NormalizeGTIN pads a barcode with leading zeros to 14 digits, so the same item
+// has the same GTIN whether it is scanned as UPC-A, EAN-13 or GTIN-14.
+func NormalizeGTIN(code string) string {
+	for len(code) < 14 {
+		code = "0" + code
+	}
+	return code
+}
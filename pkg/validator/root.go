@@ -19,6 +19,12 @@ func NewValidator() *vldtr.Validate {
 		return false
 	})
 
+	// Custom validation for GTIN family barcodes, the type follows from the length.
+	_ = validate.RegisterValidation("barcode", func(fl vldtr.FieldLevel) bool {
+		code := fl.Field().String()
+		return ValidBarcode(code, BarcodeType(code))
+	})
+
 	return validate
 }
 
@@ -0,0 +1,10 @@
+-- Recently viewed products per user, deduplicated by the primary key and
+-- trimmed to the configured number of entries per user by the application
+CREATE TABLE IF NOT EXISTS recently_viewed (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    viewed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_recently_viewed_user_viewed_at ON recently_viewed(user_id, viewed_at DESC);
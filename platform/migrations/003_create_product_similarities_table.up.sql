@@ -0,0 +1,12 @@
+-- Item-to-item similarity computed from wishlist and review co-occurrence.
+-- The table is rebuilt periodically by the recommendation worker.
+CREATE TABLE IF NOT EXISTS product_similarities (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    similar_product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    co_occurrences INT NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, similar_product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_similarities_score ON product_similarities(product_id, score DESC);
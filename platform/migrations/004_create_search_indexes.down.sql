@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_categories_name_trgm;
+DROP INDEX IF EXISTS idx_products_sku_prefix;
+DROP INDEX IF EXISTS idx_products_name_prefix;
+DROP INDEX IF EXISTS idx_products_name_trgm;
+
+-- Drop extension (optional, comment out if you want to keep it)
+-- DROP EXTENSION IF EXISTS pg_trgm;
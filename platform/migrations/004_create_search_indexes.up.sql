@@ -0,0 +1,7 @@
+-- Trigram and prefix indexes backing search-as-you-type suggestions
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (lower(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_products_name_prefix ON products (lower(name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_products_sku_prefix ON products (lower(sku) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_categories_name_trgm ON categories USING GIN (lower(name) gin_trgm_ops);
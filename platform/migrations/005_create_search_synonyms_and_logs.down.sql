@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS update_search_synonyms_modtime ON search_synonyms;
+
+DROP TABLE IF EXISTS search_clicks;
+DROP TABLE IF EXISTS search_logs;
+DROP TABLE IF EXISTS search_synonyms;
@@ -0,0 +1,38 @@
+-- Admin managed synonym groups, every term of a group matches the others ("laptop" <-> "notebook")
+CREATE TABLE IF NOT EXISTS search_synonyms (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    terms TEXT[] NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_synonyms_terms ON search_synonyms USING GIN (terms);
+
+CREATE TRIGGER update_search_synonyms_modtime
+    BEFORE UPDATE ON search_synonyms
+    FOR EACH ROW
+    EXECUTE FUNCTION update_modified_column();
+
+-- Product searches and their result counts, for zero-result analytics
+CREATE TABLE IF NOT EXISTS search_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    query VARCHAR(100) NOT NULL, -- normalized: trimmed and lower case
+    result_count INT NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_logs_query ON search_logs(query);
+CREATE INDEX IF NOT EXISTS idx_search_logs_created_at ON search_logs(created_at);
+
+-- Clicks on search results
+CREATE TABLE IF NOT EXISTS search_clicks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    query VARCHAR(100) NOT NULL, -- normalized: trimmed and lower case
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_clicks_query ON search_clicks(query);
+CREATE INDEX IF NOT EXISTS idx_search_clicks_created_at ON search_clicks(created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notification_outbox;
+DROP TRIGGER IF EXISTS update_saved_searches_modtime ON saved_searches;
+DROP TABLE IF EXISTS saved_searches;
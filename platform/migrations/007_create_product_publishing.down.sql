@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS update_product_drafts_modtime ON product_drafts;
+DROP TABLE IF EXISTS product_drafts;
+
+DROP INDEX IF EXISTS idx_products_publish_at;
+DROP INDEX IF EXISTS idx_products_publish_status;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS publish_status;
@@ -0,0 +1,31 @@
+-- Publishing lifecycle: draft -> in_review -> scheduled -> published -> archived.
+-- Existing products were live already, so they start out published.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS publish_status VARCHAR(20) NOT NULL DEFAULT 'published',
+    ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP,   -- when a scheduled product goes live
+    ADD COLUMN IF NOT EXISTS published_at TIMESTAMP;
+
+UPDATE products SET published_at = created_at WHERE publish_status = 'published' AND published_at IS NULL;
+
+ALTER TABLE products ALTER COLUMN publish_status SET DEFAULT 'draft';
+
+CREATE INDEX IF NOT EXISTS idx_products_publish_status ON products(publish_status);
+CREATE INDEX IF NOT EXISTS idx_products_publish_at ON products(publish_at) WHERE publish_status = 'scheduled';
+
+-- Pending edits of published products, kept apart from the live row until published
+CREATE TABLE IF NOT EXISTS product_drafts (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    data JSONB NOT NULL, -- the edited product, in the product input format
+    status VARCHAR(20) NOT NULL DEFAULT 'draft', -- draft, in_review or scheduled
+    publish_at TIMESTAMP,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_drafts_publish_at ON product_drafts(publish_at) WHERE status = 'scheduled';
+
+CREATE TRIGGER update_product_drafts_modtime
+    BEFORE UPDATE ON product_drafts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_modified_column();
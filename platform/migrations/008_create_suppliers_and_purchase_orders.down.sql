@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS update_purchase_orders_modtime ON purchase_orders;
+DROP TRIGGER IF EXISTS update_product_suppliers_modtime ON product_suppliers;
+DROP TRIGGER IF EXISTS update_suppliers_modtime ON suppliers;
+
+DROP TABLE IF EXISTS purchase_order_lines;
+DROP TABLE IF EXISTS purchase_orders;
+DROP SEQUENCE IF EXISTS purchase_order_number_seq;
+DROP TABLE IF EXISTS product_suppliers;
+DROP TABLE IF EXISTS suppliers;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_inventory_warehouse;
+ALTER TABLE inventory_movements DROP COLUMN IF EXISTS warehouse_id;
+
+DROP TRIGGER IF EXISTS update_warehouse_stock_modtime ON warehouse_stock;
+DROP TRIGGER IF EXISTS update_warehouses_modtime ON warehouses;
+
+DROP TABLE IF EXISTS warehouse_stock;
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,59 @@
+-- Warehouses holding stock. Exactly one is the default warehouse, which receives
+-- stock set directly on a product and goods receipts without a warehouse.
+CREATE TABLE IF NOT EXISTS warehouses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    address TEXT,
+    is_active BOOLEAN DEFAULT TRUE,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    is_deleted BOOLEAN DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_warehouses_default ON warehouses(is_default) WHERE is_default;
+
+CREATE TRIGGER update_warehouses_modtime
+    BEFORE UPDATE ON warehouses
+    FOR EACH ROW
+    EXECUTE FUNCTION update_modified_column();
+
+-- Stock level of a product in a warehouse; products.stock_quantity is the sum over warehouses
+CREATE TABLE IF NOT EXISTS warehouse_stock (
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (warehouse_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_warehouse_stock_product ON warehouse_stock(product_id);
+
+CREATE TRIGGER update_warehouse_stock_modtime
+    BEFORE UPDATE ON warehouse_stock
+    FOR EACH ROW
+    EXECUTE FUNCTION update_modified_column();
+
+-- Movements happen in a warehouse; transfers are recorded as a transfer_out and a
+-- transfer_in movement sharing the same reference_id
+ALTER TABLE inventory_movements
+    ADD COLUMN IF NOT EXISTS warehouse_id UUID REFERENCES warehouses(id);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_warehouse ON inventory_movements(warehouse_id);
+
+-- Existing stock and movements belong to the main warehouse
+INSERT INTO warehouses (code, name, is_active, is_default)
+VALUES ('MAIN', 'Main warehouse', TRUE, TRUE)
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO warehouse_stock (warehouse_id, product_id, quantity)
+SELECT w.id, p.id, GREATEST(p.stock_quantity, 0)
+FROM products p
+CROSS JOIN warehouses w
+WHERE w.code = 'MAIN'
+ON CONFLICT (warehouse_id, product_id) DO NOTHING;
+
+UPDATE inventory_movements
+SET warehouse_id = (SELECT id FROM warehouses WHERE code = 'MAIN')
+WHERE warehouse_id IS NULL;
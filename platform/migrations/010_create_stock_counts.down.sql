@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_stock_counts_modtime ON stock_counts;
+
+DROP TABLE IF EXISTS stock_count_lines;
+DROP TABLE IF EXISTS stock_counts;
@@ -0,0 +1,35 @@
+-- Cycle count sessions: open -> submitted -> approved, or cancelled
+CREATE TABLE IF NOT EXISTS stock_counts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    submitted_at TIMESTAMP,
+    approved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    approved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_counts_warehouse ON stock_counts(warehouse_id);
+CREATE INDEX IF NOT EXISTS idx_stock_counts_status ON stock_counts(status);
+
+CREATE TRIGGER update_stock_counts_modtime
+    BEFORE UPDATE ON stock_counts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_modified_column();
+
+-- Products of a count session. The system quantity is recorded when the product is
+-- counted, so stock moving during the session does not show up as a variance.
+CREATE TABLE IF NOT EXISTS stock_count_lines (
+    stock_count_id UUID NOT NULL REFERENCES stock_counts(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    expected_quantity INT,
+    counted_quantity INT CHECK (counted_quantity >= 0),
+    counted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    counted_at TIMESTAMP,
+    PRIMARY KEY (stock_count_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_count_lines_product ON stock_count_lines(product_id);
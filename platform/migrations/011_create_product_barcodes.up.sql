@@ -0,0 +1,14 @@
+-- Barcodes of products (EAN-8, UPC-A, EAN-13, GTIN-14). The GTIN is the code padded
+-- to 14 digits, so a code scanned as UPC-A or EAN-13 finds the same product.
+CREATE TABLE IF NOT EXISTS product_barcodes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    code VARCHAR(14) NOT NULL,
+    type VARCHAR(10) NOT NULL,
+    gtin CHAR(14) UNIQUE NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_barcodes_product ON product_barcodes(product_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_barcodes_primary ON product_barcodes(product_id) WHERE is_primary;
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS maintain_category_closure_update ON categories;
+DROP TRIGGER IF EXISTS maintain_category_closure_insert ON categories;
+DROP FUNCTION IF EXISTS maintain_category_closure();
+DROP TABLE IF EXISTS category_closure;
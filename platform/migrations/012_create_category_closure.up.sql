@@ -0,0 +1,63 @@
+-- Closure table of the category tree: one row per category and each of its ancestors,
+-- including the category itself at depth 0. Triggers keep it in step with parent_id.
+CREATE TABLE IF NOT EXISTS category_closure (
+    ancestor_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    descendant_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    depth INT NOT NULL,
+    PRIMARY KEY (ancestor_id, descendant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_closure_descendant ON category_closure(descendant_id);
+
+-- Fill in the existing tree
+INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+WITH RECURSIVE closure AS (
+    SELECT id AS ancestor_id, id AS descendant_id, 0 AS depth, ARRAY[id] AS path
+    FROM categories
+    UNION ALL
+    SELECT cl.ancestor_id, c.id, cl.depth + 1, cl.path || c.id
+    FROM closure cl
+    JOIN categories c ON c.parent_id = cl.descendant_id
+    WHERE NOT c.id = ANY(cl.path)
+)
+SELECT ancestor_id, descendant_id, MIN(depth)
+FROM closure
+GROUP BY ancestor_id, descendant_id
+ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION maintain_category_closure()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+        SELECT NEW.id, NEW.id, 0
+        UNION ALL
+        SELECT ancestor_id, NEW.id, depth + 1
+        FROM category_closure
+        WHERE descendant_id = NEW.parent_id;
+    ELSIF NEW.parent_id IS DISTINCT FROM OLD.parent_id THEN
+        -- Detach the subtree from its old ancestors
+        DELETE FROM category_closure
+        WHERE descendant_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = NEW.id)
+          AND ancestor_id NOT IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = NEW.id);
+
+        -- Attach it below the ancestors of the new parent
+        INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+        SELECT p.ancestor_id, s.descendant_id, p.depth + s.depth + 1
+        FROM category_closure p
+        CROSS JOIN category_closure s
+        WHERE p.descendant_id = NEW.parent_id AND s.ancestor_id = NEW.id;
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER maintain_category_closure_insert
+    AFTER INSERT ON categories
+    FOR EACH ROW
+    EXECUTE FUNCTION maintain_category_closure();
+
+CREATE TRIGGER maintain_category_closure_update
+    AFTER UPDATE OF parent_id ON categories
+    FOR EACH ROW
+    EXECUTE FUNCTION maintain_category_closure();
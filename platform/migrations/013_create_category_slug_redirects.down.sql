@@ -0,0 +1 @@
+DROP TABLE IF EXISTS category_slug_redirects;
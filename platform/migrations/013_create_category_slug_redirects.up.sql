@@ -0,0 +1,9 @@
+-- Slugs of categories merged into another category, so old links keep working.
+-- A redirect is only used while no category has the slug.
+CREATE TABLE IF NOT EXISTS category_slug_redirects (
+    slug VARCHAR(50) PRIMARY KEY,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_slug_redirects_category ON category_slug_redirects(category_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_product_categories_pinned;
+
+ALTER TABLE product_categories DROP COLUMN IF EXISTS pinned_at;
+ALTER TABLE product_categories DROP COLUMN IF EXISTS pin_position;
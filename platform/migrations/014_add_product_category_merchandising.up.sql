@@ -0,0 +1,6 @@
+-- Manual merchandising: products pinned within a category get a position, counted from 0.
+-- Unpinned products have no position and follow the fallback order of the listing.
+ALTER TABLE product_categories ADD COLUMN IF NOT EXISTS pin_position INT CHECK (pin_position >= 0);
+ALTER TABLE product_categories ADD COLUMN IF NOT EXISTS pinned_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_product_categories_pinned ON product_categories(category_id, pin_position) WHERE pin_position IS NOT NULL;
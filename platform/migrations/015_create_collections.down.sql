@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_collections_modtime ON collections;
+
+DROP TABLE IF EXISTS collection_overrides;
+DROP TABLE IF EXISTS collections;
@@ -0,0 +1,29 @@
+-- Smart collections: products are selected by the rules (GetProducts filter names plus
+-- attribute values and tags), adjusted by manual include/exclude overrides.
+CREATE TABLE IF NOT EXISTS collections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    description TEXT,
+    rules JSONB NOT NULL DEFAULT '{}'::jsonb,
+    sort_by VARCHAR(20) NOT NULL DEFAULT 'created_at',
+    sort_order VARCHAR(4) NOT NULL DEFAULT 'desc',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS collection_overrides (
+    collection_id UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    mode VARCHAR(10) NOT NULL CHECK (mode IN ('include', 'exclude')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (collection_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_overrides_product ON collection_overrides(product_id);
+
+CREATE TRIGGER update_collections_modtime
+    BEFORE UPDATE ON collections
+    FOR EACH ROW
+    EXECUTE FUNCTION update_modified_column();
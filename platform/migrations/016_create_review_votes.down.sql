@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_reviews_product_helpful;
+
+ALTER TABLE reviews DROP COLUMN IF EXISTS not_helpful_votes;
+ALTER TABLE reviews ALTER COLUMN helpful_votes DROP NOT NULL;
+
+DROP TABLE IF EXISTS review_votes;
@@ -0,0 +1,18 @@
+-- Helpful votes: one vote per user and review, counted on the review.
+-- The counters are recomputed from review_votes while the review row is locked.
+CREATE TABLE IF NOT EXISTS review_votes (
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    is_helpful BOOLEAN NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (review_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_votes_user ON review_votes(user_id);
+
+UPDATE reviews SET helpful_votes = 0 WHERE helpful_votes IS NULL;
+ALTER TABLE reviews ALTER COLUMN helpful_votes SET NOT NULL;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS not_helpful_votes INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_reviews_product_helpful ON reviews(product_id, helpful_votes DESC) WHERE is_deleted = FALSE;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_reviews_moderation;
+
+ALTER TABLE reviews DROP COLUMN IF EXISTS moderated_at;
+ALTER TABLE reviews DROP COLUMN IF EXISTS moderated_by;
+ALTER TABLE reviews DROP COLUMN IF EXISTS rejection_reason;
+ALTER TABLE reviews DROP COLUMN IF EXISTS moderation_status;
@@ -0,0 +1,10 @@
+-- Review moderation: new reviews wait for an admin, only approved reviews are public.
+-- Reviews written before moderation existed stay public.
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderation_status VARCHAR(20) NOT NULL DEFAULT 'approved'
+    CHECK (moderation_status IN ('pending', 'approved', 'rejected'));
+ALTER TABLE reviews ALTER COLUMN moderation_status SET DEFAULT 'pending';
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS rejection_reason TEXT;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderated_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_reviews_moderation ON reviews(moderation_status, created_at) WHERE is_deleted = FALSE;
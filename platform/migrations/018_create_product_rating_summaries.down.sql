@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS maintain_product_rating_summary_update ON reviews;
+DROP TRIGGER IF EXISTS maintain_product_rating_summary_insert_delete ON reviews;
+DROP FUNCTION IF EXISTS maintain_product_rating_summary();
+DROP TABLE IF EXISTS product_rating_summaries;
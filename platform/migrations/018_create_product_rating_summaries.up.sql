@@ -0,0 +1,74 @@
+-- Rating summary of each product: approved reviews counted per star, with their total.
+-- A trigger on reviews applies each change as a delta, so the summary never rescans reviews.
+CREATE TABLE IF NOT EXISTS product_rating_summaries (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    review_count INT NOT NULL DEFAULT 0 CHECK (review_count >= 0),
+    rating_total INT NOT NULL DEFAULT 0,
+    rating_1 INT NOT NULL DEFAULT 0,
+    rating_2 INT NOT NULL DEFAULT 0,
+    rating_3 INT NOT NULL DEFAULT 0,
+    rating_4 INT NOT NULL DEFAULT 0,
+    rating_5 INT NOT NULL DEFAULT 0,
+    average_rating NUMERIC(3, 2) GENERATED ALWAYS AS (
+        CASE WHEN review_count > 0 THEN ROUND(rating_total::numeric / review_count, 2) ELSE 0 END
+    ) STORED,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_rating_summaries_average ON product_rating_summaries(average_rating DESC, review_count DESC);
+
+-- Fill in the existing reviews
+INSERT INTO product_rating_summaries (product_id, review_count, rating_total, rating_1, rating_2, rating_3, rating_4, rating_5)
+SELECT product_id, COUNT(*), SUM(rating),
+       COUNT(*) FILTER (WHERE rating = 1), COUNT(*) FILTER (WHERE rating = 2), COUNT(*) FILTER (WHERE rating = 3),
+       COUNT(*) FILTER (WHERE rating = 4), COUNT(*) FILTER (WHERE rating = 5)
+FROM reviews
+WHERE is_deleted = FALSE AND moderation_status = 'approved'
+GROUP BY product_id
+ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION maintain_product_rating_summary()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Take out the old review when it was counted
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.is_deleted = FALSE AND OLD.moderation_status = 'approved' THEN
+        UPDATE product_rating_summaries
+        SET review_count = review_count - 1,
+            rating_total = rating_total - OLD.rating,
+            rating_1 = rating_1 - (OLD.rating = 1)::int,
+            rating_2 = rating_2 - (OLD.rating = 2)::int,
+            rating_3 = rating_3 - (OLD.rating = 3)::int,
+            rating_4 = rating_4 - (OLD.rating = 4)::int,
+            rating_5 = rating_5 - (OLD.rating = 5)::int,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE product_id = OLD.product_id;
+    END IF;
+
+    -- Add the new review when it counts
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.is_deleted = FALSE AND NEW.moderation_status = 'approved' THEN
+        INSERT INTO product_rating_summaries AS s (product_id, review_count, rating_total, rating_1, rating_2, rating_3, rating_4, rating_5)
+        VALUES (NEW.product_id, 1, NEW.rating, (NEW.rating = 1)::int, (NEW.rating = 2)::int, (NEW.rating = 3)::int,
+                (NEW.rating = 4)::int, (NEW.rating = 5)::int)
+        ON CONFLICT (product_id) DO UPDATE
+        SET review_count = s.review_count + 1,
+            rating_total = s.rating_total + EXCLUDED.rating_total,
+            rating_1 = s.rating_1 + EXCLUDED.rating_1,
+            rating_2 = s.rating_2 + EXCLUDED.rating_2,
+            rating_3 = s.rating_3 + EXCLUDED.rating_3,
+            rating_4 = s.rating_4 + EXCLUDED.rating_4,
+            rating_5 = s.rating_5 + EXCLUDED.rating_5,
+            updated_at = CURRENT_TIMESTAMP;
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER maintain_product_rating_summary_insert_delete
+    AFTER INSERT OR DELETE ON reviews
+    FOR EACH ROW
+    EXECUTE FUNCTION maintain_product_rating_summary();
+
+CREATE TRIGGER maintain_product_rating_summary_update
+    AFTER UPDATE OF product_id, rating, moderation_status, is_deleted ON reviews
+    FOR EACH ROW
+    EXECUTE FUNCTION maintain_product_rating_summary();
@@ -0,0 +1,11 @@
+-- Photos attached to reviews. The files are kept by the configured storage under storage_key.
+CREATE TABLE IF NOT EXISTS review_photos (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    content_type VARCHAR(50) NOT NULL,
+    size_bytes INT NOT NULL CHECK (size_bytes > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_photos_review ON review_photos(review_id, created_at);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS maintain_product_wishlist_count ON wishlist;
+DROP FUNCTION IF EXISTS maintain_product_wishlist_count();
+DROP TABLE IF EXISTS product_wishlist_counts;
@@ -0,0 +1,36 @@
+-- Number of wishlists holding each product, kept by a trigger on wishlist so that
+-- suggestions can rank by popularity without counting wishlists per request. Together
+-- with the approved review count of product_rating_summaries it makes the popularity.
+CREATE TABLE IF NOT EXISTS product_wishlist_counts (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    wishlist_count INT NOT NULL DEFAULT 0 CHECK (wishlist_count >= 0)
+);
+
+-- Fill in the existing wishlists
+INSERT INTO product_wishlist_counts (product_id, wishlist_count)
+SELECT product_id, COUNT(*)
+FROM wishlist
+GROUP BY product_id
+ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION maintain_product_wishlist_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE product_wishlist_counts
+        SET wishlist_count = wishlist_count - 1
+        WHERE product_id = OLD.product_id;
+    ELSE
+        INSERT INTO product_wishlist_counts AS w (product_id, wishlist_count)
+        VALUES (NEW.product_id, 1)
+        ON CONFLICT (product_id) DO UPDATE
+        SET wishlist_count = w.wishlist_count + 1;
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER maintain_product_wishlist_count
+    AFTER INSERT OR DELETE ON wishlist
+    FOR EACH ROW
+    EXECUTE FUNCTION maintain_product_wishlist_count();
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_reviews_modtime ON reviews;
+
+CREATE TRIGGER update_reviews_modtime
+    BEFORE UPDATE ON reviews
+    FOR EACH ROW
+    EXECUTE FUNCTION update_modified_column();
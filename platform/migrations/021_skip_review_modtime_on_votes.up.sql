@@ -0,0 +1,13 @@
+-- Vote counters are not review content: only changes to the content of a review move
+-- its updated_at, so votes do not change the review validators
+DROP TRIGGER IF EXISTS update_reviews_modtime ON reviews;
+
+CREATE TRIGGER update_reviews_modtime
+    BEFORE UPDATE ON reviews
+    FOR EACH ROW
+    WHEN ((OLD.product_id, OLD.user_id, OLD.rating, OLD.title, OLD.comment, OLD.is_verified_purchase,
+           OLD.is_deleted, OLD.moderation_status, OLD.rejection_reason, OLD.moderated_by, OLD.moderated_at)
+          IS DISTINCT FROM
+          (NEW.product_id, NEW.user_id, NEW.rating, NEW.title, NEW.comment, NEW.is_verified_purchase,
+           NEW.is_deleted, NEW.moderation_status, NEW.rejection_reason, NEW.moderated_by, NEW.moderated_at))
+    EXECUTE FUNCTION update_modified_column();
@@ -0,0 +1,19 @@
+package notification
+
+import (
+	"golang-test1/platform/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogNotifier writes notifications to the application log, for development
+type LogNotifier struct{}
+
+func (n *LogNotifier) Notify(notification Notification) error {
+	logger.GetLogger().WithFields(logrus.Fields{
+		"user_id": notification.UserID,
+		"type":    notification.Type,
+		"data":    notification.Data,
+	}).Infof("notification: %s - %s", notification.Subject, notification.Body)
+	return nil
+}
@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"golang-test1/pkg/config"
+	"golang-test1/platform/database"
+
+	"github.com/google/uuid"
+)
+
+// Notification is a message to a user
+type Notification struct {
+	UserID  uuid.UUID
+	Type    string
+	Subject string
+	Body    string
+	Data    map[string]any
+}
+
+// Notifier delivers notifications to users
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+var notifier Notifier = &LogNotifier{}
+
+// SetUpNotifier selects the notifier from the configured driver, the database outbox by default
+func SetUpNotifier() {
+	switch config.NotificationCfg().Driver {
+	case "log":
+		notifier = &LogNotifier{}
+	default:
+		notifier = NewOutboxNotifier(database.GetDB())
+	}
+}
+
+// GetNotifier returns the default notifier
+func GetNotifier() Notifier {
+	return notifier
+}
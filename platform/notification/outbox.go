@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"encoding/json"
+	"golang-test1/platform/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxNotifier stores notifications in the notification_outbox table,
+// from which a relay delivers them and sets sent_at
+type OutboxNotifier struct {
+	db *database.DB
+}
+
+func NewOutboxNotifier(db *database.DB) *OutboxNotifier {
+	return &OutboxNotifier{
+		db: db,
+	}
+}
+
+func (n *OutboxNotifier) Notify(notification Notification) error {
+	dataJSON, err := json.Marshal(notification.Data)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO notification_outbox (id, user_id, type, subject, body, data, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = n.db.Exec(
+		query,
+		uuid.New(),
+		notification.UserID,
+		notification.Type,
+		notification.Subject,
+		notification.Body,
+		dataJSON,
+		time.Now(),
+	)
+	return err
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"golang-test1/pkg/config"
+	"io"
+)
+
+// Storage keeps uploaded files under slash-separated keys
+type Storage interface {
+	// Save stores the content under the key, replacing an earlier file
+	Save(key string, content io.Reader) error
+	// Delete removes the file of the key, without error when there is none
+	Delete(key string) error
+	// URL returns where clients download the file of the key
+	URL(key string) string
+}
+
+var storage Storage = NewLocalStorage("./uploads", "/uploads")
+
+// SetUpStorage selects the storage from the configured driver, the local filesystem by default
+func SetUpStorage() {
+	cfg := config.StorageCfg()
+	switch cfg.Driver {
+	default:
+		storage = NewLocalStorage(cfg.LocalPath, cfg.PublicURL)
+	}
+}
+
+// GetStorage returns the default storage
+func GetStorage() Storage {
+	return storage
+}